@@ -19,16 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CarClientService_SendRoute_FullMethodName  = "/CarClientService/SendRoute"
-	CarClientService_GetCarInfo_FullMethodName = "/CarClientService/GetCarInfo"
+	CarClientService_ReplaceItinerary_FullMethodName = "/CarClientService/ReplaceItinerary"
+	CarClientService_AppendItinerary_FullMethodName  = "/CarClientService/AppendItinerary"
+	CarClientService_CancelItinerary_FullMethodName  = "/CarClientService/CancelItinerary"
+	CarClientService_CancelRoute_FullMethodName      = "/CarClientService/CancelRoute"
+	CarClientService_Recall_FullMethodName           = "/CarClientService/Recall"
+	CarClientService_GetCarInfo_FullMethodName       = "/CarClientService/GetCarInfo"
+	CarClientService_Gossip_FullMethodName           = "/CarClientService/Gossip"
+	CarClientService_PingReq_FullMethodName          = "/CarClientService/PingReq"
+	CarClientService_SubscribeCarInfo_FullMethodName = "/CarClientService/SubscribeCarInfo"
+	CarClientService_SendCommand_FullMethodName      = "/CarClientService/SendCommand"
 )
 
 // CarClientServiceClient is the client API for CarClientService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CarClientServiceClient interface {
-	SendRoute(ctx context.Context, in *Route, opts ...grpc.CallOption) (*RouteResponse, error)
+	ReplaceItinerary(ctx context.Context, in *Itinerary, opts ...grpc.CallOption) (*ItineraryAck, error)
+	AppendItinerary(ctx context.Context, in *Itinerary, opts ...grpc.CallOption) (*ItineraryAck, error)
+	CancelItinerary(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*ItineraryAck, error)
+	CancelRoute(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*ItineraryAck, error)
+	Recall(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ItineraryAck, error)
 	GetCarInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CarInfo, error)
+	Gossip(ctx context.Context, in *GossipMessage, opts ...grpc.CallOption) (*GossipMessage, error)
+	PingReq(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	SubscribeCarInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CarClientService_SubscribeCarInfoClient, error)
+	SendCommand(ctx context.Context, in *Command, opts ...grpc.CallOption) (*CommandResponse, error)
 }
 
 type carClientServiceClient struct {
@@ -39,9 +55,45 @@ func NewCarClientServiceClient(cc grpc.ClientConnInterface) CarClientServiceClie
 	return &carClientServiceClient{cc}
 }
 
-func (c *carClientServiceClient) SendRoute(ctx context.Context, in *Route, opts ...grpc.CallOption) (*RouteResponse, error) {
-	out := new(RouteResponse)
-	err := c.cc.Invoke(ctx, CarClientService_SendRoute_FullMethodName, in, out, opts...)
+func (c *carClientServiceClient) ReplaceItinerary(ctx context.Context, in *Itinerary, opts ...grpc.CallOption) (*ItineraryAck, error) {
+	out := new(ItineraryAck)
+	err := c.cc.Invoke(ctx, CarClientService_ReplaceItinerary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carClientServiceClient) AppendItinerary(ctx context.Context, in *Itinerary, opts ...grpc.CallOption) (*ItineraryAck, error) {
+	out := new(ItineraryAck)
+	err := c.cc.Invoke(ctx, CarClientService_AppendItinerary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carClientServiceClient) CancelItinerary(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*ItineraryAck, error) {
+	out := new(ItineraryAck)
+	err := c.cc.Invoke(ctx, CarClientService_CancelItinerary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carClientServiceClient) CancelRoute(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*ItineraryAck, error) {
+	out := new(ItineraryAck)
+	err := c.cc.Invoke(ctx, CarClientService_CancelRoute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carClientServiceClient) Recall(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ItineraryAck, error) {
+	out := new(ItineraryAck)
+	err := c.cc.Invoke(ctx, CarClientService_Recall_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -57,12 +109,79 @@ func (c *carClientServiceClient) GetCarInfo(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *carClientServiceClient) Gossip(ctx context.Context, in *GossipMessage, opts ...grpc.CallOption) (*GossipMessage, error) {
+	out := new(GossipMessage)
+	err := c.cc.Invoke(ctx, CarClientService_Gossip_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carClientServiceClient) PingReq(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, CarClientService_PingReq_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *carClientServiceClient) SubscribeCarInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CarClientService_SubscribeCarInfoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CarClientService_ServiceDesc.Streams[0], CarClientService_SubscribeCarInfo_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &carClientServiceSubscribeCarInfoClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CarClientService_SubscribeCarInfoClient interface {
+	Recv() (*CarInfo, error)
+	grpc.ClientStream
+}
+
+type carClientServiceSubscribeCarInfoClient struct {
+	grpc.ClientStream
+}
+
+func (x *carClientServiceSubscribeCarInfoClient) Recv() (*CarInfo, error) {
+	m := new(CarInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *carClientServiceClient) SendCommand(ctx context.Context, in *Command, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CarClientService_SendCommand_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CarClientServiceServer is the server API for CarClientService service.
 // All implementations must embed UnimplementedCarClientServiceServer
 // for forward compatibility
 type CarClientServiceServer interface {
-	SendRoute(context.Context, *Route) (*RouteResponse, error)
+	ReplaceItinerary(context.Context, *Itinerary) (*ItineraryAck, error)
+	AppendItinerary(context.Context, *Itinerary) (*ItineraryAck, error)
+	CancelItinerary(context.Context, *CancelRequest) (*ItineraryAck, error)
+	CancelRoute(context.Context, *CancelRequest) (*ItineraryAck, error)
+	Recall(context.Context, *Empty) (*ItineraryAck, error)
 	GetCarInfo(context.Context, *Empty) (*CarInfo, error)
+	Gossip(context.Context, *GossipMessage) (*GossipMessage, error)
+	PingReq(context.Context, *PingRequest) (*PingResponse, error)
+	SubscribeCarInfo(*Empty, CarClientService_SubscribeCarInfoServer) error
+	SendCommand(context.Context, *Command) (*CommandResponse, error)
 	mustEmbedUnimplementedCarClientServiceServer()
 }
 
@@ -70,12 +189,36 @@ type CarClientServiceServer interface {
 type UnimplementedCarClientServiceServer struct {
 }
 
-func (UnimplementedCarClientServiceServer) SendRoute(context.Context, *Route) (*RouteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendRoute not implemented")
+func (UnimplementedCarClientServiceServer) ReplaceItinerary(context.Context, *Itinerary) (*ItineraryAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceItinerary not implemented")
+}
+func (UnimplementedCarClientServiceServer) AppendItinerary(context.Context, *Itinerary) (*ItineraryAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendItinerary not implemented")
+}
+func (UnimplementedCarClientServiceServer) CancelItinerary(context.Context, *CancelRequest) (*ItineraryAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelItinerary not implemented")
+}
+func (UnimplementedCarClientServiceServer) CancelRoute(context.Context, *CancelRequest) (*ItineraryAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRoute not implemented")
+}
+func (UnimplementedCarClientServiceServer) Recall(context.Context, *Empty) (*ItineraryAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recall not implemented")
 }
 func (UnimplementedCarClientServiceServer) GetCarInfo(context.Context, *Empty) (*CarInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCarInfo not implemented")
 }
+func (UnimplementedCarClientServiceServer) Gossip(context.Context, *GossipMessage) (*GossipMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Gossip not implemented")
+}
+func (UnimplementedCarClientServiceServer) PingReq(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PingReq not implemented")
+}
+func (UnimplementedCarClientServiceServer) SubscribeCarInfo(*Empty, CarClientService_SubscribeCarInfoServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeCarInfo not implemented")
+}
+func (UnimplementedCarClientServiceServer) SendCommand(context.Context, *Command) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendCommand not implemented")
+}
 func (UnimplementedCarClientServiceServer) mustEmbedUnimplementedCarClientServiceServer() {}
 
 // UnsafeCarClientServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -89,20 +232,92 @@ func RegisterCarClientServiceServer(s grpc.ServiceRegistrar, srv CarClientServic
 	s.RegisterService(&CarClientService_ServiceDesc, srv)
 }
 
-func _CarClientService_SendRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Route)
+func _CarClientService_ReplaceItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Itinerary)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).ReplaceItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_ReplaceItinerary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).ReplaceItinerary(ctx, req.(*Itinerary))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarClientService_AppendItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Itinerary)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).AppendItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_AppendItinerary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).AppendItinerary(ctx, req.(*Itinerary))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarClientService_CancelItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).CancelItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_CancelItinerary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).CancelItinerary(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarClientService_CancelRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CarClientServiceServer).SendRoute(ctx, in)
+		return srv.(CarClientServiceServer).CancelRoute(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CarClientService_SendRoute_FullMethodName,
+		FullMethod: CarClientService_CancelRoute_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CarClientServiceServer).SendRoute(ctx, req.(*Route))
+		return srv.(CarClientServiceServer).CancelRoute(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarClientService_Recall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).Recall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_Recall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).Recall(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -125,6 +340,81 @@ func _CarClientService_GetCarInfo_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CarClientService_Gossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).Gossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_Gossip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).Gossip(ctx, req.(*GossipMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarClientService_PingReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).PingReq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_PingReq_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).PingReq(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CarClientService_SubscribeCarInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CarClientServiceServer).SubscribeCarInfo(m, &carClientServiceSubscribeCarInfoServer{stream})
+}
+
+type CarClientService_SubscribeCarInfoServer interface {
+	Send(*CarInfo) error
+	grpc.ServerStream
+}
+
+type carClientServiceSubscribeCarInfoServer struct {
+	grpc.ServerStream
+}
+
+func (x *carClientServiceSubscribeCarInfoServer) Send(m *CarInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CarClientService_SendCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Command)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CarClientServiceServer).SendCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CarClientService_SendCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CarClientServiceServer).SendCommand(ctx, req.(*Command))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CarClientService_ServiceDesc is the grpc.ServiceDesc for CarClientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -133,27 +423,81 @@ var CarClientService_ServiceDesc = grpc.ServiceDesc{
 	HandlerType: (*CarClientServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "SendRoute",
-			Handler:    _CarClientService_SendRoute_Handler,
+			MethodName: "ReplaceItinerary",
+			Handler:    _CarClientService_ReplaceItinerary_Handler,
+		},
+		{
+			MethodName: "AppendItinerary",
+			Handler:    _CarClientService_AppendItinerary_Handler,
+		},
+		{
+			MethodName: "CancelItinerary",
+			Handler:    _CarClientService_CancelItinerary_Handler,
+		},
+		{
+			MethodName: "CancelRoute",
+			Handler:    _CarClientService_CancelRoute_Handler,
+		},
+		{
+			MethodName: "Recall",
+			Handler:    _CarClientService_Recall_Handler,
 		},
 		{
 			MethodName: "GetCarInfo",
 			Handler:    _CarClientService_GetCarInfo_Handler,
 		},
+		{
+			MethodName: "Gossip",
+			Handler:    _CarClientService_Gossip_Handler,
+		},
+		{
+			MethodName: "PingReq",
+			Handler:    _CarClientService_PingReq_Handler,
+		},
+		{
+			MethodName: "SendCommand",
+			Handler:    _CarClientService_SendCommand_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeCarInfo",
+			Handler:       _CarClientService_SubscribeCarInfo_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services.proto",
 }
 
 const (
-	CoordinatorService_SendCarInfo_FullMethodName = "/CoordinatorService/SendCarInfo"
+	CoordinatorService_Register_FullMethodName       = "/CoordinatorService/Register"
+	CoordinatorService_SendCarInfo_FullMethodName    = "/CoordinatorService/SendCarInfo"
+	CoordinatorService_GetNearbyCars_FullMethodName  = "/CoordinatorService/GetNearbyCars"
+	CoordinatorService_SendCarCommand_FullMethodName = "/CoordinatorService/SendCarCommand"
+	CoordinatorService_CancelTrip_FullMethodName     = "/CoordinatorService/CancelTrip"
+	CoordinatorService_RecallCar_FullMethodName      = "/CoordinatorService/RecallCar"
+	CoordinatorService_SetFleetState_FullMethodName  = "/CoordinatorService/SetFleetState"
+	CoordinatorService_RequestRide_FullMethodName    = "/CoordinatorService/RequestRide"
+	CoordinatorService_GetTripEta_FullMethodName     = "/CoordinatorService/GetTripEta"
+	CoordinatorService_HandOver_FullMethodName       = "/CoordinatorService/HandOver"
+	CoordinatorService_ShareRoute_FullMethodName     = "/CoordinatorService/ShareRoute"
 )
 
 // CoordinatorServiceClient is the client API for CoordinatorService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CoordinatorServiceClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	SendCarInfo(ctx context.Context, in *CarInfo, opts ...grpc.CallOption) (*CarInfoResponse, error)
+	GetNearbyCars(ctx context.Context, in *NearbyRequest, opts ...grpc.CallOption) (*NearbyResponse, error)
+	SendCarCommand(ctx context.Context, in *CarCommand, opts ...grpc.CallOption) (*CommandResponse, error)
+	CancelTrip(ctx context.Context, in *CancelTripRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	RecallCar(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	SetFleetState(ctx context.Context, in *FleetStateRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	RequestRide(ctx context.Context, in *RideRequest, opts ...grpc.CallOption) (*RideResponse, error)
+	GetTripEta(ctx context.Context, in *TripRequest, opts ...grpc.CallOption) (*RideResponse, error)
+	HandOver(ctx context.Context, in *Handover, opts ...grpc.CallOption) (*CommandResponse, error)
+	ShareRoute(ctx context.Context, in *SharedRoute, opts ...grpc.CallOption) (*CommandResponse, error)
 }
 
 type coordinatorServiceClient struct {
@@ -164,6 +508,15 @@ func NewCoordinatorServiceClient(cc grpc.ClientConnInterface) CoordinatorService
 	return &coordinatorServiceClient{cc}
 }
 
+func (c *coordinatorServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_Register_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coordinatorServiceClient) SendCarInfo(ctx context.Context, in *CarInfo, opts ...grpc.CallOption) (*CarInfoResponse, error) {
 	out := new(CarInfoResponse)
 	err := c.cc.Invoke(ctx, CoordinatorService_SendCarInfo_FullMethodName, in, out, opts...)
@@ -173,11 +526,102 @@ func (c *coordinatorServiceClient) SendCarInfo(ctx context.Context, in *CarInfo,
 	return out, nil
 }
 
+func (c *coordinatorServiceClient) GetNearbyCars(ctx context.Context, in *NearbyRequest, opts ...grpc.CallOption) (*NearbyResponse, error) {
+	out := new(NearbyResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_GetNearbyCars_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) SendCarCommand(ctx context.Context, in *CarCommand, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_SendCarCommand_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) CancelTrip(ctx context.Context, in *CancelTripRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_CancelTrip_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) RecallCar(ctx context.Context, in *CarRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_RecallCar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) SetFleetState(ctx context.Context, in *FleetStateRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_SetFleetState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) RequestRide(ctx context.Context, in *RideRequest, opts ...grpc.CallOption) (*RideResponse, error) {
+	out := new(RideResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_RequestRide_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) GetTripEta(ctx context.Context, in *TripRequest, opts ...grpc.CallOption) (*RideResponse, error) {
+	out := new(RideResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_GetTripEta_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) HandOver(ctx context.Context, in *Handover, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_HandOver_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorServiceClient) ShareRoute(ctx context.Context, in *SharedRoute, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, CoordinatorService_ShareRoute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoordinatorServiceServer is the server API for CoordinatorService service.
 // All implementations must embed UnimplementedCoordinatorServiceServer
 // for forward compatibility
 type CoordinatorServiceServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	SendCarInfo(context.Context, *CarInfo) (*CarInfoResponse, error)
+	GetNearbyCars(context.Context, *NearbyRequest) (*NearbyResponse, error)
+	SendCarCommand(context.Context, *CarCommand) (*CommandResponse, error)
+	CancelTrip(context.Context, *CancelTripRequest) (*CommandResponse, error)
+	RecallCar(context.Context, *CarRequest) (*CommandResponse, error)
+	SetFleetState(context.Context, *FleetStateRequest) (*CommandResponse, error)
+	RequestRide(context.Context, *RideRequest) (*RideResponse, error)
+	GetTripEta(context.Context, *TripRequest) (*RideResponse, error)
+	HandOver(context.Context, *Handover) (*CommandResponse, error)
+	ShareRoute(context.Context, *SharedRoute) (*CommandResponse, error)
 	mustEmbedUnimplementedCoordinatorServiceServer()
 }
 
@@ -185,9 +629,39 @@ type CoordinatorServiceServer interface {
 type UnimplementedCoordinatorServiceServer struct {
 }
 
+func (UnimplementedCoordinatorServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
 func (UnimplementedCoordinatorServiceServer) SendCarInfo(context.Context, *CarInfo) (*CarInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendCarInfo not implemented")
 }
+func (UnimplementedCoordinatorServiceServer) GetNearbyCars(context.Context, *NearbyRequest) (*NearbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyCars not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) SendCarCommand(context.Context, *CarCommand) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendCarCommand not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) CancelTrip(context.Context, *CancelTripRequest) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTrip not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) RecallCar(context.Context, *CarRequest) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecallCar not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) SetFleetState(context.Context, *FleetStateRequest) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFleetState not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) RequestRide(context.Context, *RideRequest) (*RideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestRide not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) GetTripEta(context.Context, *TripRequest) (*RideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripEta not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) HandOver(context.Context, *Handover) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandOver not implemented")
+}
+func (UnimplementedCoordinatorServiceServer) ShareRoute(context.Context, *SharedRoute) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareRoute not implemented")
+}
 func (UnimplementedCoordinatorServiceServer) mustEmbedUnimplementedCoordinatorServiceServer() {}
 
 // UnsafeCoordinatorServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -201,6 +675,24 @@ func RegisterCoordinatorServiceServer(s grpc.ServiceRegistrar, srv CoordinatorSe
 	s.RegisterService(&CoordinatorService_ServiceDesc, srv)
 }
 
+func _CoordinatorService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CoordinatorService_SendCarInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CarInfo)
 	if err := dec(in); err != nil {
@@ -219,6 +711,168 @@ func _CoordinatorService_SendCarInfo_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoordinatorService_GetNearbyCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).GetNearbyCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_GetNearbyCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).GetNearbyCars(ctx, req.(*NearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_SendCarCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).SendCarCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_SendCarCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).SendCarCommand(ctx, req.(*CarCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_CancelTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).CancelTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_CancelTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).CancelTrip(ctx, req.(*CancelTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_RecallCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).RecallCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_RecallCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).RecallCar(ctx, req.(*CarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_SetFleetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FleetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).SetFleetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_SetFleetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).SetFleetState(ctx, req.(*FleetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_RequestRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).RequestRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_RequestRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).RequestRide(ctx, req.(*RideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_GetTripEta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).GetTripEta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_GetTripEta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).GetTripEta(ctx, req.(*TripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_HandOver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Handover)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).HandOver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_HandOver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).HandOver(ctx, req.(*Handover))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoordinatorService_ShareRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharedRoute)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServiceServer).ShareRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoordinatorService_ShareRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServiceServer).ShareRoute(ctx, req.(*SharedRoute))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CoordinatorService_ServiceDesc is the grpc.ServiceDesc for CoordinatorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -226,10 +880,177 @@ var CoordinatorService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "CoordinatorService",
 	HandlerType: (*CoordinatorServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _CoordinatorService_Register_Handler,
+		},
 		{
 			MethodName: "SendCarInfo",
 			Handler:    _CoordinatorService_SendCarInfo_Handler,
 		},
+		{
+			MethodName: "GetNearbyCars",
+			Handler:    _CoordinatorService_GetNearbyCars_Handler,
+		},
+		{
+			MethodName: "SendCarCommand",
+			Handler:    _CoordinatorService_SendCarCommand_Handler,
+		},
+		{
+			MethodName: "CancelTrip",
+			Handler:    _CoordinatorService_CancelTrip_Handler,
+		},
+		{
+			MethodName: "RecallCar",
+			Handler:    _CoordinatorService_RecallCar_Handler,
+		},
+		{
+			MethodName: "SetFleetState",
+			Handler:    _CoordinatorService_SetFleetState_Handler,
+		},
+		{
+			MethodName: "RequestRide",
+			Handler:    _CoordinatorService_RequestRide_Handler,
+		},
+		{
+			MethodName: "GetTripEta",
+			Handler:    _CoordinatorService_GetTripEta_Handler,
+		},
+		{
+			MethodName: "HandOver",
+			Handler:    _CoordinatorService_HandOver_Handler,
+		},
+		{
+			MethodName: "ShareRoute",
+			Handler:    _CoordinatorService_ShareRoute_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services.proto",
+}
+
+const (
+	ReplicaService_RequestVote_FullMethodName   = "/ReplicaService/RequestVote"
+	ReplicaService_AppendEntries_FullMethodName = "/ReplicaService/AppendEntries"
+)
+
+// ReplicaServiceClient is the client API for ReplicaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplicaServiceClient interface {
+	RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	AppendEntries(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error)
+}
+
+type replicaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicaServiceClient(cc grpc.ClientConnInterface) ReplicaServiceClient {
+	return &replicaServiceClient{cc}
+}
+
+func (c *replicaServiceClient) RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, ReplicaService_RequestVote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicaServiceClient) AppendEntries(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error) {
+	out := new(AppendResponse)
+	err := c.cc.Invoke(ctx, ReplicaService_AppendEntries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplicaServiceServer is the server API for ReplicaService service.
+// All implementations must embed UnimplementedReplicaServiceServer
+// for forward compatibility
+type ReplicaServiceServer interface {
+	RequestVote(context.Context, *VoteRequest) (*VoteResponse, error)
+	AppendEntries(context.Context, *AppendRequest) (*AppendResponse, error)
+	mustEmbedUnimplementedReplicaServiceServer()
+}
+
+// UnimplementedReplicaServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReplicaServiceServer struct {
+}
+
+func (UnimplementedReplicaServiceServer) RequestVote(context.Context, *VoteRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedReplicaServiceServer) AppendEntries(context.Context, *AppendRequest) (*AppendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (UnimplementedReplicaServiceServer) mustEmbedUnimplementedReplicaServiceServer() {}
+
+// UnsafeReplicaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplicaServiceServer will
+// result in compilation errors.
+type UnsafeReplicaServiceServer interface {
+	mustEmbedUnimplementedReplicaServiceServer()
+}
+
+func RegisterReplicaServiceServer(s grpc.ServiceRegistrar, srv ReplicaServiceServer) {
+	s.RegisterService(&ReplicaService_ServiceDesc, srv)
+}
+
+func _ReplicaService_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServiceServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplicaService_RequestVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServiceServer).RequestVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReplicaService_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicaServiceServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReplicaService_AppendEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicaServiceServer).AppendEntries(ctx, req.(*AppendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReplicaService_ServiceDesc is the grpc.ServiceDesc for ReplicaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReplicaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ReplicaService",
+	HandlerType: (*ReplicaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestVote",
+			Handler:    _ReplicaService_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _ReplicaService_AppendEntries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services.proto",
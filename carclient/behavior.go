@@ -0,0 +1,214 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/utils"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Behavior decides how a car moves. Step is called whenever the car reached
+// a cell, or once per second while it stands, and moves the car by at most
+// one cell; the drive loop drives the edge at the car's speed.
+type Behavior interface {
+	Step(c *Car)
+}
+
+var (
+	behaviorMutex sync.Mutex
+	behaviors     = make(map[string]func() Behavior)
+)
+
+// RegisterBehavior makes a behavior selectable by name. The factory is called
+// once per selection, so behaviors may keep per-car state.
+func RegisterBehavior(name string, factory func() Behavior) {
+	behaviorMutex.Lock()
+	defer behaviorMutex.Unlock()
+	behaviors[name] = factory
+}
+
+func newBehavior(name string) (Behavior, error) {
+	behaviorMutex.Lock()
+	defer behaviorMutex.Unlock()
+
+	factory, ok := behaviors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown behavior %q, available: %v", name, behaviorNames())
+	}
+	return factory(), nil
+}
+
+func behaviorNames() []string {
+	names := make([]string, 0, len(behaviors))
+	for name := range behaviors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterBehavior("idle", func() Behavior { return idle{} })
+	RegisterBehavior("randomCruise", func() Behavior { return randomCruise{} })
+	RegisterBehavior("repulsive", func() Behavior { return repulsive{} })
+	RegisterBehavior("predictive", func() Behavior { return predictive{} })
+	RegisterBehavior("returnToDepot", func() Behavior { return returnToDepot{} })
+	RegisterBehavior("patrol", func() Behavior { return &patrol{} })
+	RegisterBehavior("followRoute", func() Behavior { return followRoute{} })
+}
+
+// rebalance drives towards a demand hotspot chosen by the coordinator and
+// falls back to the previous behavior once it got there.
+type rebalance struct {
+	target   *api.Coordinate
+	previous string
+}
+
+const rebalanceBehavior = "rebalance"
+
+// reposition starts driving to target on behalf of the coordinator.
+func (c *Car) reposition(target *api.Coordinate) error {
+	if target == nil || target.X < 0 || target.X >= int32(c.GridWidth) || target.Y < 0 || target.Y >= int32(c.GridHeight) {
+		return fmt.Errorf("invalid reposition target %v", target)
+	}
+
+	c.mu.Lock()
+	position := c.CarInfo.Position
+	c.mu.Unlock()
+	atTarget := position.X == target.X && position.Y == target.Y
+	if !atTarget && len(utils.CalculatePathFor(position, target, c.vehicle)) == 0 {
+		return fmt.Errorf("reposition target %v cannot be reached by a %s", target, c.vehicle.Name)
+	}
+
+	c.mu.Lock()
+	previous := c.CarInfo.Behavior
+	if current, ok := c.behavior.(*rebalance); ok {
+		previous = current.previous
+	}
+	c.behavior = &rebalance{target: target, previous: previous}
+	c.CarInfo.Behavior = rebalanceBehavior
+	c.stopped = false
+	c.idleSince = time.Now()
+	c.mu.Unlock()
+	c.logger("behavior").Info("Repositioning", "target", target)
+	return nil
+}
+
+func (r *rebalance) Step(c *Car) {
+	if !c.at(r.target) {
+		c.stepTowards(r.target)
+		return
+	}
+	if err := c.setBehavior(r.previous); err != nil {
+		c.logger("behavior").Warn("Failed to restore behavior after repositioning", "err", err)
+	}
+}
+
+// setBehavior switches the car to the named behavior.
+func (c *Car) setBehavior(name string) error {
+	behavior, err := newBehavior(name)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.behavior = behavior
+	c.CarInfo.Behavior = name
+	c.stopped = false
+	c.idleSince = time.Now()
+	c.mu.Unlock()
+	c.logger("behavior").Info("Behavior set", "behavior", name)
+	return nil
+}
+
+// commandBehavior switches to a behavior sent by the coordinator, which is
+// kept instead of returning to the depot once the car is idle.
+func (c *Car) commandBehavior(name string) error {
+	if err := c.setBehavior(name); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.commanded = true
+	c.mu.Unlock()
+	return nil
+}
+
+// stepTowards moves the car one cell along the shortest path to target.
+func (c *Car) stepTowards(target *api.Coordinate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := utils.CalculatePathFor(c.CarInfo.Position, target, c.vehicle)
+	if len(path) > 1 {
+		c.CarInfo.Position = path[1]
+	}
+}
+
+// canEnter reports whether pos is on the grid and on a road the car may use.
+func (c *Car) canEnter(pos *api.Coordinate) bool {
+	if pos.X < 0 || pos.X >= int32(c.GridWidth) || pos.Y < 0 || pos.Y >= int32(c.GridHeight) {
+		return false
+	}
+	return c.vehicle.Allows(pos)
+}
+
+func (c *Car) at(target *api.Coordinate) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.CarInfo.Position.X == target.X && c.CarInfo.Position.Y == target.Y
+}
+
+// idle keeps the car where it is.
+type idle struct{}
+
+func (idle) Step(c *Car) {}
+
+// randomCruise drives randomly without reversing.
+type randomCruise struct{}
+
+func (randomCruise) Step(c *Car) { c.randomDrive() }
+
+// repulsive keeps away from the current positions of peers.
+type repulsive struct{}
+
+func (repulsive) Step(c *Car) { c.advancedDrive() }
+
+// predictive keeps away from the predicted paths of peers.
+type predictive struct{}
+
+func (predictive) Step(c *Car) { c.predictiveDrive() }
+
+// returnToDepot drives back to the depot and parks there.
+type returnToDepot struct{}
+
+func (returnToDepot) Step(c *Car) {
+	if !c.at(c.Home) {
+		c.stepTowards(c.Home)
+	}
+}
+
+// patrol circles the grid along waypoints just inside its border.
+type patrol struct {
+	next int
+}
+
+func (p *patrol) Step(c *Car) {
+	waypoints := []*api.Coordinate{
+		{X: 1, Y: 1},
+		{X: int32(c.GridWidth - 2), Y: 1},
+		{X: int32(c.GridWidth - 2), Y: int32(c.GridHeight - 2)},
+		{X: 1, Y: int32(c.GridHeight - 2)},
+	}
+	if c.at(waypoints[p.next]) {
+		p.next = (p.next + 1) % len(waypoints)
+	}
+	c.stepTowards(waypoints[p.next])
+}
+
+// followRoute drives the itinerary received from the coordinator. The drive loop
+// uses it whenever an itinerary is active, regardless of the selected behavior.
+type followRoute struct{}
+
+func (followRoute) Step(c *Car) { c.stepItinerary() }
@@ -0,0 +1,19 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"testing"
+)
+
+func TestRepositionRejectsUnreachableTargets(t *testing.T) {
+	car := testCar(t, "car", &api.Coordinate{X: 1, Y: 1})
+	if err := car.reposition(&api.Coordinate{X: 10, Y: 10}); err == nil {
+		t.Error("expected a car to refuse a target in the pedestrian zone")
+	}
+	if car.CarInfo.Behavior == rebalanceBehavior {
+		t.Error("car switched to rebalancing for an unreachable target")
+	}
+	if err := car.reposition(&api.Coordinate{X: 8, Y: 10}); err != nil {
+		t.Errorf("expected a reachable target to be accepted, got %v", err)
+	}
+}
@@ -2,35 +2,67 @@ package carclient
 
 import (
 	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
 	"AutonomousCarFleetSimulation/utils"
 	"context"
+	"crypto/ed25519"
 	"flag"
 	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Car struct {
-	CarInfo     *api.CarInfo
-	Conn        *grpc.ClientConn
-	Client      api.CoordinatorServiceClient
-	GridWidth   int
-	GridHeight  int
-	LastMoveDir int // 0: up, 1: down, 2: left, 3: right
-	mu          sync.Mutex
-	peerMutex   sync.Mutex
-	peers       map[string]*api.CarInfo
-	advancedD   bool
+	CarInfo          *api.CarInfo
+	Conn             *grpc.ClientConn
+	Client           api.CoordinatorServiceClient
+	coordinators     []string          // addresses of the coordinator replicas
+	coordinatorIndex int               // replica Conn was dialed to unless redirected
+	linkMutex        sync.Mutex        // guards Conn, Client, coordinatorIndex, token, oldToken and coordinatorKey
+	token            string            // proves the car id in reports, empty until registered
+	oldToken         string            // last rejected token, proves the car id when registering again
+	coordinatorKey   ed25519.PublicKey // verifies the tokens of routes and commands
+	GridWidth        int
+	GridHeight       int
+	LastMoveDir      int             // 0: up, 1: down, 2: left, 3: right
+	Home             *api.Coordinate // depot the car returns to when idle
+	idleTimeout      time.Duration   // idle time after which the car returns to its depot, 0 = never
+	idleSince        time.Time
+	commanded        bool // behavior was set by a command and is not replaced by returnToDepot when idle
+	energy           EnergyModel
+	motion           Motion
+	vehicle          utils.VehicleType // decides which roads the car may use
+	leg              *api.Stop         // stop the itinerary was heading to in the last step
+	charging         bool              // set while the car is on its way to or at a charging station
+	completedStops   map[string]bool   // stops already served, ignored when the coordinator resends them
+	stopped          bool              // set after an aborted route until the next itinerary or command
+	halted           bool              // set while the fleet is paused or emergency stopped
+	reportCh         chan struct{}     // pending report to the coordinator, see updateCoordinator
+	connected        atomic.Bool       // set while reports reach the coordinator
+	mu               sync.Mutex
+	peerMutex        sync.Mutex
+	peers            map[string]*api.CarInfo
+	connMutex        sync.Mutex
+	peerConns        map[string]*grpc.ClientConn // long-lived connections per peer
+	subscriptions    map[string]context.CancelFunc
+	subMutex         sync.Mutex
+	subscribers      map[chan *api.CarInfo]struct{}
+	behavior         Behavior
+	sensingRadius    int         // only peers within this Manhattan distance are tracked, 0 = unlimited
+	gossip           *membership // nil unless gossip mode is enabled
+	log              *slog.Logger
 }
 
-func newCar(identifier string, startPos *api.Coordinate, color string, advancedD bool) *Car {
-	// Establish a connection to the car client service via gRPC
-	conn, err := grpc.Dial("localhost:50000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+func newCar(identifier, id string, coordinators []string, startPos *api.Coordinate, color string, depot int, vehicle utils.VehicleType, seats int) *Car {
+	// Establish a connection to the coordinator via gRPC
+	conn, err := dialCoordinator(coordinators[0])
 	if err != nil {
-		fmt.Println("Failed to connect to car client service:", err)
+		slog.Error("Failed to connect to coordinator", logging.CarKey, identifier, "err", err)
 		return nil
 	}
 
@@ -38,36 +70,51 @@ func newCar(identifier string, startPos *api.Coordinate, color string, advancedD
 
 	return &Car{
 		CarInfo: &api.CarInfo{
-			Identifier:  identifier,
-			Position:    startPos,
-			Route:       &api.Route{Coordinates: []*api.Coordinate{}}, // Empty route to start with
-			ActiveRoute: false,
-			Color:       color,
+			Identifier:   identifier,
+			CarId:        id,
+			Position:     startPos,
+			Route:        &api.Route{Coordinates: []*api.Coordinate{}}, // Empty route to start with
+			ActiveRoute:  false,
+			Color:        color,
+			Depot:        int32(depot),
+			SeatCapacity: int32(seats),
+			VehicleType:  vehicle.Name,
 		},
-		Conn:        conn,
-		Client:      client,
-		GridWidth:   utils.Settings.GridSize,       // Assuming the grid size is 8, adjust if needed
-		GridHeight:  utils.Settings.GridSize,       // Assuming the grid size is 8, adjust if needed
-		LastMoveDir: -1,                            // Initialize to an invalid direction
-		peers:       make(map[string]*api.CarInfo), // Initialize peers map
-		advancedD:   advancedD,
+		Conn:           conn,
+		Client:         client,
+		coordinators:   coordinators,
+		GridWidth:      utils.Settings.GridSize, // Assuming the grid size is 8, adjust if needed
+		GridHeight:     utils.Settings.GridSize, // Assuming the grid size is 8, adjust if needed
+		LastMoveDir:    -1,                      // Initialize to an invalid direction
+		Home:           utils.Settings.Depots[depot],
+		vehicle:        vehicle,
+		idleSince:      time.Now(),
+		completedStops: make(map[string]bool),
+		peers:          make(map[string]*api.CarInfo), // Initialize peers map
+		peerConns:      make(map[string]*grpc.ClientConn),
+		subscriptions:  make(map[string]context.CancelFunc),
+		subscribers:    make(map[chan *api.CarInfo]struct{}),
+		reportCh:       make(chan struct{}, 1),
+		behavior:       randomCruise{},
+		log:            slog.With(logging.CarKey, identifier),
 	}
 }
 
-func (c *Car) updateCoordinator() {
-	// Create and send a CarInfo request
-	c.mu.Lock()
-	resp, err := c.Client.SendCarInfo(context.Background(), c.CarInfo)
-	if err != nil {
-		fmt.Println("Error sending car info:", err)
-		return
-	}
-	c.mu.Unlock()
-
-	fmt.Println("Response from server:", resp.Message)
+// logger returns the car's logger tagged with a component name.
+func (c *Car) logger(component string) *slog.Logger {
+	return c.log.With(logging.ComponentKey, component)
 }
 
 func (c *Car) discoverPeers() {
+	if c.gossip != nil {
+		c.runGossip()
+		return
+	}
+	if c.sensingRadius > 0 {
+		c.discoverNearbyPeers()
+		return
+	}
+
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
@@ -81,96 +128,195 @@ func (c *Car) discoverPeers() {
 			}
 
 			// Skip peers which are already in peer group
-			exists := false
-			for _, peer := range c.peers {
-				if peer.Identifier == address {
-					exists = true
-					break
-				}
-			}
+			c.peerMutex.Lock()
+			_, exists := c.peers[address]
+			c.peerMutex.Unlock()
 			if exists {
 				continue
 			}
 
-			conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			conn, err := grpc.Dial(address, security.DialOption())
 			if err != nil {
 				continue
 			}
 
 			client := api.NewCarClientServiceClient(conn)
 			resp, err := client.GetCarInfo(context.Background(), &api.Empty{})
+			conn.Close()
 			if err != nil {
-				conn.Close()
 				continue
 			}
-			c.peerMutex.Lock()
-			c.peers[resp.Identifier] = resp
-			c.peerMutex.Unlock()
-			conn.Close()
-			fmt.Println("Found Peer at: %s", address)
-			fmt.Println("%s", c.peers[resp.Identifier].Position)
+			if !c.inRange(resp.Position) {
+				continue
+			}
+			c.addPeer(resp)
+			c.logger("peers").Info("Found peer", "peer", address, "position", resp.Position)
 		}
 	}
-	fmt.Print(c.peers)
 }
 
-func (c *Car) updatePeers() {
-	ticker := time.NewTicker(5 * time.Second)
+// discoverNearbyPeers asks the coordinator's spatial index for cars within the sensing radius.
+func (c *Car) discoverNearbyPeers() {
+	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
 	for range ticker.C {
-		c.peerMutex.Lock()
-		for id, peer := range c.peers {
-			conn, err := grpc.Dial(peer.Identifier, grpc.WithTransportCredentials(insecure.NewCredentials()))
-			if err != nil {
-				fmt.Printf("Failed to connect to peer %s: %v\n", peer.Identifier, err)
-				delete(c.peers, id)
-				continue
-			}
-
-			client := api.NewCarClientServiceClient(conn)
-			resp, err := client.GetCarInfo(context.Background(), &api.Empty{})
-			if err != nil || resp == nil {
-				fmt.Printf("Failed to update peer info for %s: %v\n", peer.Identifier, err)
-				conn.Close()
-				delete(c.peers, id)
-				continue
-			}
-
-			c.peers[resp.Identifier] = resp
-			conn.Close()
+		self := c.selfInfo()
+		resp, err := c.coordinatorClient().GetNearbyCars(context.Background(), &api.NearbyRequest{
+			Identifier: self.Identifier,
+			Position:   self.Position,
+			Radius:     int32(c.sensingRadius),
+		})
+		if err != nil {
+			c.logger("peers").Warn("Failed to query nearby cars", "err", err)
+			continue
+		}
+		for _, peer := range resp.Cars {
+			c.addPeer(peer)
 		}
-		c.peerMutex.Unlock()
 	}
 }
 
+// inRange reports whether pos is within the sensing radius of the car.
+func (c *Car) inRange(pos *api.Coordinate) bool {
+	if c.sensingRadius <= 0 {
+		return true
+	}
+	if pos == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return utils.Distance(c.CarInfo.Position, pos) <= float64(c.sensingRadius)
+}
+
 func Run() {
 	// Parse console args
 	port := flag.Int("port", 50001, "Port for the server to listen on")
+	id := flag.String("id", "", "Stable id of the car, independent of its address (empty = car-<port>)")
 	color := flag.String("color", "", "Color of car")
 	x := flag.Int("x", 3, "X Coordinate to start")
 	y := flag.Int("y", 3, "Y Coordinate to start")
-	advancedD := flag.Bool("advancedDrive", false, "AdvancedDrive Function")
+	behavior := flag.String("behavior", "randomCruise", "Driving behavior while no route is active, one of "+fmt.Sprint(behaviorNames()))
+	advancedD := flag.Bool("advancedDrive", false, "AdvancedDrive Function (same as -behavior=repulsive)")
+	predictiveD := flag.Bool("predictiveDrive", false, "Avoid the predicted paths of peers (same as -behavior=predictive)")
+	gossip := flag.Bool("gossip", false, "Discover peers via gossip instead of scanning local ports")
+	seeds := flag.String("seeds", "", "Comma separated seed addresses for gossip mode")
+	depot := flag.Int("depot", -1, "Index of the home depot (-1 = nearest to start position)")
+	idleTimeout := flag.Duration("idleTimeout", 30*time.Second, "Return to the depot after being idle this long (0 = never)")
+	vehicleType := flag.String("type", utils.DefaultVehicleType, "Vehicle type, one of "+fmt.Sprint(utils.VehicleTypeNames()))
+	seats := flag.Int("seats", 0, "Number of passengers the car can carry at once (0 = default of the vehicle type)")
+	capacity := flag.Float64("capacity", 100, "Energy of a full battery")
+	consumption := flag.Float64("consumption", 1, "Energy consumed per cell driven")
+	idleDrain := flag.Float64("idleDrain", 0.1, "Energy consumed per second without moving")
+	chargeRate := flag.Float64("chargeRate", 10, "Energy recharged per second at a charging station")
+	lowEnergy := flag.Float64("lowEnergy", 0.2, "Fraction of the capacity below which the car goes charging")
+	maxSpeed := flag.Float64("maxSpeed", 0, "Top speed in cells per second (0 = default of the vehicle type)")
+	acceleration := flag.Float64("acceleration", 2, "Acceleration in cells per second squared (0 = instant)")
+	metricsAddress := flag.String("metrics", "", "Address to serve Prometheus metrics on, e.g. :2113 (empty = disabled)")
+	sensingRadius := flag.Int("sensingRadius", 0, "Only track peers within this distance (0 = all peers)")
+	logLevel := flag.String("logLevel", "info", "Log level, one of debug, info, warn or error")
+	logJSON := flag.Bool("logJSON", false, "Write logs as JSON lines")
+	tlsCA := flag.String("tlsCA", "", "CA certificate for mutual TLS (empty = insecure connections)")
+	tlsCert := flag.String("tlsCert", "", "Own certificate for mutual TLS")
+	tlsKey := flag.String("tlsKey", "", "Key of the own certificate")
+	coordinators := flag.String("coordinators", "localhost:50000", "Comma separated addresses of the coordinator replicas, the car follows redirects to the leader")
 	flag.Parse()
 
+	if err := logging.Setup(*logLevel, *logJSON); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := security.SetupTLS(*tlsCA, *tlsCert, *tlsKey); err != nil {
+		slog.Error("Failed to set up TLS", "err", err)
+		return
+	}
+
+	vehicle, ok := utils.LookupVehicleType(*vehicleType)
+	if !ok {
+		slog.Error("Unknown vehicle type", "type", *vehicleType, "available", utils.VehicleTypeNames())
+		return
+	}
+
+	// Start on a cell the vehicle may drive on, or it would never move
 	startPos := &api.Coordinate{X: int32(*x), Y: int32(*y)}
+	if allowed := vehicle.NearestAllowed(startPos); allowed.X != startPos.X || allowed.Y != startPos.Y {
+		slog.Warn("Start position not allowed for the vehicle type, starting at the nearest allowed cell", "type", vehicle.Name, "x", allowed.X, "y", allowed.Y)
+		startPos = allowed
+	}
+
+	if *depot < 0 {
+		*depot = utils.NearestDepot(startPos)
+	}
+	if *depot >= len(utils.Settings.Depots) {
+		slog.Error("Depot does not exist", "depot", *depot, "depots", len(utils.Settings.Depots))
+		return
+	}
 
-	println(fmt.Sprintf("localhost:%d", *port))
-	car := newCar(fmt.Sprintf("localhost:%d", *port), startPos, *color, *advancedD)
+	if *seats == 0 {
+		*seats = int(vehicle.Seats)
+	}
+	if *maxSpeed == 0 {
+		*maxSpeed = vehicle.MaxSpeed
+	}
+
+	if *id == "" {
+		*id = fmt.Sprintf("car-%d", *port)
+	}
+
+	coordinatorAddresses := parseSeeds(*coordinators)
+	if len(coordinatorAddresses) == 0 {
+		slog.Error("At least one coordinator address is required")
+		return
+	}
+	car := newCar(fmt.Sprintf("localhost:%d", *port), *id, coordinatorAddresses, startPos, *color, *depot, vehicle, *seats)
 	if car == nil {
-		fmt.Println("Failed to create car client")
+		slog.Error("Failed to create car client")
+		return
+	}
+	car.sensingRadius = *sensingRadius
+	car.idleTimeout = *idleTimeout
+	car.setEnergyModel(EnergyModel{
+		Capacity:     *capacity,
+		PerCell:      *consumption,
+		IdleDrain:    *idleDrain,
+		ChargeRate:   *chargeRate,
+		LowThreshold: *lowEnergy,
+	})
+	if *maxSpeed < 0 {
+		slog.Error("maxSpeed must be positive")
+		return
+	}
+	car.setMotion(Motion{MaxSpeed: *maxSpeed, Acceleration: *acceleration})
+	switch {
+	case *predictiveD:
+		*behavior = "predictive"
+	case *advancedD:
+		*behavior = "repulsive"
+	}
+	if err := car.setBehavior(*behavior); err != nil {
+		slog.Error("Failed to set behavior", "err", err)
 		return
 	}
-	fmt.Printf("Starting car: %+v\n", car.CarInfo)
+	if *gossip {
+		car.gossip = newMembership(car.CarInfo.Identifier, parseSeeds(*seeds))
+	}
+	car.log.Info("Starting car", "info", car.CarInfo)
+
+	if *metricsAddress != "" {
+		go car.serveMetrics(*metricsAddress)
+	}
 
 	// Start the car client gRPC server
 	go car.startCarClientServer(fmt.Sprintf(":%d", *port))
 
+	go car.reportToCoordinator() // Send position updates to the coordinator, reconnecting if it is down
+
 	go car.drive() // Start driving in a separate goroutine
 
 	go car.discoverPeers() // Start peer discovery in a separate goroutine
 
-	go car.updatePeers() // Ask periodically for positon updates of all peers
+	go car.updatePeers() // Keep a position subscription open to every known peer
 
 	select {} // Block forever
 }
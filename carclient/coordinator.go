@@ -0,0 +1,196 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/coordinator/ha"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	reportTimeout = 2 * time.Second
+	minRetryDelay = 500 * time.Millisecond
+	maxRetryDelay = 10 * time.Second
+)
+
+// dialCoordinator creates the connection to a coordinator. gRPC connects
+// lazily and reconnects on its own, with the backoff capped at maxRetryDelay so
+// a restarted coordinator is found again quickly.
+func dialCoordinator(address string) (*grpc.ClientConn, error) {
+	return grpc.Dial(address,
+		security.DialOption(),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.Config{BaseDelay: minRetryDelay, Multiplier: 1.6, Jitter: 0.2, MaxDelay: maxRetryDelay},
+			MinConnectTimeout: reportTimeout,
+		}),
+		grpc.WithUnaryInterceptor(countUnaryErrors), grpc.WithStreamInterceptor(countStreamErrors))
+}
+
+// coordinatorClient returns the client of the coordinator the car currently talks to.
+func (c *Car) coordinatorClient() api.CoordinatorServiceClient {
+	c.linkMutex.Lock()
+	defer c.linkMutex.Unlock()
+	return c.Client
+}
+
+// switchCoordinator connects to another coordinator replica.
+func (c *Car) switchCoordinator(address string) {
+	conn, err := dialCoordinator(address)
+	if err != nil {
+		c.logger("coordinator").Warn("Failed to connect to coordinator", "address", address, "err", err)
+		return
+	}
+
+	c.linkMutex.Lock()
+	old := c.Conn
+	c.Conn = conn
+	c.Client = api.NewCoordinatorServiceClient(conn)
+	c.linkMutex.Unlock()
+
+	old.Close()
+	c.logger("coordinator").Info("Switched coordinator", "address", address)
+}
+
+// failover picks the coordinator for the next attempt after a failed report:
+// the leader a replica redirected to, or the next replica if the current one
+// is unreachable.
+func (c *Car) failover(err error, trailer metadata.MD) {
+	if leader := ha.RedirectTarget(err, trailer); leader != "" {
+		c.switchCoordinator(leader)
+		return
+	}
+	if len(c.coordinators) < 2 {
+		return
+	}
+	if code := status.Code(err); code != codes.Unavailable && code != codes.DeadlineExceeded {
+		return
+	}
+	c.linkMutex.Lock()
+	c.coordinatorIndex = (c.coordinatorIndex + 1) % len(c.coordinators)
+	next := c.coordinators[c.coordinatorIndex]
+	c.linkMutex.Unlock()
+	c.switchCoordinator(next)
+}
+
+// updateCoordinator schedules a report of the car's state to the coordinator
+// and never blocks. Updates are coalesced: while a report is pending or the
+// coordinator is unreachable only the latest state is sent.
+func (c *Car) updateCoordinator() {
+	select {
+	case c.reportCh <- struct{}{}:
+	default: // The pending report picks up the latest state
+	}
+}
+
+// reportToCoordinator sends the scheduled reports and retries with
+// exponential backoff while the coordinator is down. The car keeps driving
+// meanwhile.
+func (c *Car) reportToCoordinator() {
+	for range c.reportCh {
+		delay := minRetryDelay
+		for !c.sendCarInfo() {
+			time.Sleep(delay/2 + time.Duration(rand.Int63n(int64(delay/2)))) // Jitter so restarted coordinators are not flooded
+			delay = min(delay*2, maxRetryDelay)
+		}
+	}
+}
+
+// register identifies the car to the coordinator and stores the token for its
+// reports and the key the coordinator's routes and commands are checked with.
+func (c *Car) register() error {
+	c.mu.Lock()
+	req := &api.RegisterRequest{CarId: c.CarInfo.CarId, Address: c.CarInfo.Identifier}
+	c.mu.Unlock()
+
+	// The coordinator only registers an id which is still online again for
+	// the holder of its previous token
+	ctx := context.Background()
+	c.linkMutex.Lock()
+	if c.oldToken != "" {
+		ctx = security.WithToken(ctx, c.oldToken)
+	}
+	c.linkMutex.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.coordinatorClient().Register(ctx, req, grpc.Trailer(&trailer))
+	if err != nil {
+		c.failover(err, trailer)
+		return err
+	}
+
+	c.linkMutex.Lock()
+	c.token = resp.Token
+	c.oldToken = ""
+	c.coordinatorKey = resp.CoordinatorKey
+	c.linkMutex.Unlock()
+	c.logger("coordinator").Info("Registered", "id", req.CarId)
+	return nil
+}
+
+func (c *Car) authToken() string {
+	c.linkMutex.Lock()
+	defer c.linkMutex.Unlock()
+	return c.token
+}
+
+// sendCarInfo sends the full CarInfo, so a coordinator which lost its state
+// gets the position, itinerary and trip progress of the car back with the
+// first report after reconnecting.
+func (c *Car) sendCarInfo() bool {
+	token := c.authToken()
+	if token == "" {
+		if err := c.register(); err != nil {
+			c.connected.Store(false)
+			c.logger("coordinator").Warn("Failed to register, retrying", "err", err)
+			return false
+		}
+		token = c.authToken()
+	}
+
+	c.mu.Lock()
+	info := proto.Clone(c.CarInfo).(*api.CarInfo)
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(security.WithToken(context.Background(), token), reportTimeout)
+	defer cancel()
+	var trailer metadata.MD
+	resp, err := c.coordinatorClient().SendCarInfo(ctx, info, grpc.Trailer(&trailer))
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		// The coordinator restarted with another key, the token expired or
+		// the id or address was registered again while the car was offline
+		c.linkMutex.Lock()
+		c.oldToken, c.token = c.token, ""
+		c.linkMutex.Unlock()
+	}
+	if err != nil {
+		if c.connected.Swap(false) {
+			c.logger("coordinator").Warn("Lost connection to coordinator, retrying", "err", err)
+		} else {
+			c.logger("coordinator").Debug("Coordinator still unreachable", "err", err)
+		}
+		c.failover(err, trailer)
+		return false
+	}
+
+	c.mu.Lock()
+	c.CarInfo.AbortedTrips = c.CarInfo.AbortedTrips[len(info.AbortedTrips):] // Aborts are reported once, later ones stay queued
+	c.mu.Unlock()
+
+	if !c.connected.Swap(true) {
+		c.logger("coordinator").Info("Connected to coordinator", "response", resp.Message)
+	} else {
+		c.logger("coordinator").Debug("Car info sent", "response", resp.Message)
+	}
+	return true
+}
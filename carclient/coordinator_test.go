@@ -0,0 +1,66 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCoordinator rejects reports with the first token it issued, like a
+// coordinator whose registry bound the car id to another address meanwhile.
+type fakeCoordinator struct {
+	api.CoordinatorServiceServer
+	mu            sync.Mutex
+	registrations int
+}
+
+func (f *fakeCoordinator) Register(ctx context.Context, req *api.RegisterRequest) (*api.RegisterResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.registrations++
+	return &api.RegisterResponse{Token: fmt.Sprintf("%s-%d", req.CarId, f.registrations)}, nil
+}
+
+func (f *fakeCoordinator) SendCarInfo(ctx context.Context, req *api.CarInfo) (*api.CarInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.registrations < 2 {
+		return nil, status.Error(codes.PermissionDenied, "registered again")
+	}
+	return &api.CarInfoResponse{Message: "ok"}, nil
+}
+
+func TestRejectedReportRegistersAgain(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinator := &fakeCoordinator{}
+	server := grpc.NewServer()
+	api.RegisterCoordinatorServiceServer(server, coordinator)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	car := testCar(t, "car", &api.Coordinate{X: 1, Y: 1})
+	car.switchCoordinator(listener.Addr().String())
+
+	if car.sendCarInfo() {
+		t.Fatal("expected the report with the rebound token to fail")
+	}
+	if car.authToken() != "" || car.oldToken != "car-test-1" {
+		t.Fatalf("expected the rejected token to be kept for registering again, got token %q and old token %q", car.authToken(), car.oldToken)
+	}
+	if !car.sendCarInfo() {
+		t.Fatal("expected the car to register again and report")
+	}
+	if coordinator.registrations != 2 {
+		t.Errorf("expected two registrations, got %d", coordinator.registrations)
+	}
+}
@@ -2,6 +2,7 @@ package carclient
 
 import (
 	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
 	"AutonomousCarFleetSimulation/utils"
 	"fmt"
 	"math"
@@ -9,86 +10,191 @@ import (
 	"time"
 )
 
+const (
+	predictionHorizon = 5                      // cells the predictive drive looks ahead
+	tickInterval      = 250 * time.Millisecond // simulation step of the movement
+	decisionInterval  = 1 * time.Second        // how often a standing car reconsiders
+)
+
 func (c *Car) drive() {
-	for {
+	var lastDecision, lastReport time.Time
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
 		c.mu.Lock()
-		if c.CarInfo.ActiveRoute && len(c.CarInfo.Route.Coordinates) > 0 {
-			c.mu.Unlock()
-			fmt.Println("Switching to driveRoute mode")
-			c.driveRoute()
-		} else {
-			if c.advancedD {
-				// Advanced Drive
-				c.mu.Unlock()
-				c.advancedDrive()
-			} else {
-				// Random Drive
-				c.mu.Unlock()
-				c.randomDrive()
+		halted := c.halted
+		c.mu.Unlock()
+		if halted {
+			// Fleet is paused: hold the position but keep reporting, so the
+			// car stays online and its id registered
+			if time.Since(lastReport) >= decisionInterval {
+				c.positionChanged()
+				lastReport = time.Now()
 			}
+			continue
+		}
+
+		decide := time.Since(lastDecision) >= decisionInterval
+		cells, behavior := c.move(tickInterval.Seconds(), decide)
+		if behavior != nil {
+			lastDecision = time.Now()
 		}
+
+		carMoves.Add(float64(cells))
 		c.mu.Lock()
-		fmt.Printf("Driving to new position: X: %d, Y: %d\n", c.CarInfo.Position.X, c.CarInfo.Position.Y)
+		c.consumeEnergy(cells, tickInterval.Seconds())
+		moving := c.CarInfo.Speed > 0
+		if cells > 0 {
+			c.logger("drive").Debug("Driving to new position", "x", c.CarInfo.Position.X, "y", c.CarInfo.Position.Y, "speed", c.CarInfo.Speed)
+		}
 		c.mu.Unlock()
-		c.updateCoordinator()       // Send updated position to the coordinator
-		time.Sleep(1 * time.Second) // Simulate driving time
+
+		if moving || cells > 0 || time.Since(lastReport) >= decisionInterval {
+			c.positionChanged() // Send updated position to peers and the coordinator
+			lastReport = time.Now()
+		}
 	}
 }
 
-func (c *Car) randomDrive() {
-	var moveDirection int
+// currentBehavior picks the behavior for the next cell. Itineraries, low
+// energy and aborted routes override the selected behavior. Must be called
+// with c.mu held.
+func (c *Car) currentBehavior() Behavior {
+	behavior := c.behavior
+	_, rebalancing := behavior.(*rebalance)
+	switch {
+	case c.CarInfo.Energy <= 0 && utils.ChargingStationAt(c.CarInfo.Position) < 0:
+		// Out of energy: the car cannot move anymore
+		behavior = idle{}
+	case c.CarInfo.ActiveRoute && len(c.CarInfo.Itinerary) > 0:
+		behavior = followRoute{}
+	case c.charging || c.lowEnergy():
+		if !c.charging {
+			c.logger("energy").Info("Energy low, heading to a charging station", "energy", c.CarInfo.Energy)
+		}
+		c.charging = true
+		behavior = charge{}
+	case c.stopped:
+		// Route was aborted: hold at a safe cell until the next command
+		behavior = safeStop{}
+	case !rebalancing && !c.commanded && c.idleTimeout > 0 && time.Since(c.idleSince) > c.idleTimeout:
+		// Idle for too long: head back to the depot and park there
+		behavior = returnToDepot{}
+	}
+	return behavior
+}
 
-	for {
-		moveDirection = rand.Intn(4) // Randomly choose between 0 (up), 1 (down), 2 (left), 3 (right)
+// halt freezes the car in place until it is resumed. An emergency stop also
+// aborts all trips, which is reported to the coordinator right away.
+func (c *Car) halt(emergency bool) {
+	if emergency {
+		c.cancelItinerary(nil)
+	}
 
-		if moveDirection == c.oppositeDirection() {
-			continue // Skip if it is the opposite of the last move
-		}
+	c.mu.Lock()
+	c.halted = true
+	c.CarInfo.Speed = 0 // Resumes from standstill on the current edge
+	c.mu.Unlock()
+
+	if emergency {
+		c.logger("fleet").Warn("Emergency stop")
+		c.updateCoordinator()
+	} else {
+		c.logger("fleet").Info("Paused")
+	}
+}
 
-		// Create a new instance of api.Coordinate
-		newPosition := &api.Coordinate{
-			X: c.CarInfo.Position.X,
-			Y: c.CarInfo.Position.Y,
+func (c *Car) resume() {
+	c.mu.Lock()
+	c.halted = false
+	c.idleSince = time.Now()
+	c.mu.Unlock()
+	c.logger("fleet").Info("Resumed")
+}
+
+// updateState derives the reported CarState from the behavior which moved the
+// car last. Must be called with c.mu held.
+func (c *Car) updateState(behavior Behavior) {
+	atHome := c.CarInfo.Position.X == c.Home.X && c.CarInfo.Position.Y == c.Home.Y
+	state := api.CarState_CRUISING
+
+	switch behavior.(type) {
+	case charge:
+		if utils.ChargingStationAt(c.CarInfo.Position) >= 0 {
+			state = api.CarState_CHARGING
+		}
+	case followRoute:
+		if c.CarInfo.ActiveRoute {
+			state = api.CarState_ON_ROUTE
+		}
+	case returnToDepot:
+		state = api.CarState_RETURNING
+		if atHome {
+			state = api.CarState_PARKED
+		}
+	case idle:
+		if atHome {
+			state = api.CarState_PARKED
 		}
+	}
 
-		switch moveDirection {
-		case 0:
-			// Move vertically up
-			if newPosition.Y > 0 {
-				newPosition.Y -= 1
-			} else {
-				continue // Skip if the move is out of bounds
-			}
-		case 1:
-			// Move vertically down
-			if newPosition.Y < int32(c.GridHeight-1) {
-				newPosition.Y += 1
-			} else {
-				continue // Skip if the move is out of bounds
-			}
-		case 2:
-			// Move horizontally left
-			if newPosition.X > 0 {
-				newPosition.X -= 1
-			} else {
-				continue // Skip if the move is out of bounds
-			}
-		case 3:
-			// Move horizontally right
-			if newPosition.X < int32(c.GridWidth-1) {
-				newPosition.X += 1
-			} else {
-				continue // Skip if the move is out of bounds
-			}
+	if c.CarInfo.Energy <= 0 && state != api.CarState_CHARGING {
+		state = api.CarState_OUT_OF_ENERGY
+	}
+
+	if state != c.CarInfo.State {
+		c.logger("drive").Info("State changed", "from", c.CarInfo.State, "to", state)
+	}
+	c.CarInfo.State = state
+}
+
+// randomDrive moves to a random neighbouring cell the vehicle may enter
+// without reversing the last move. Dead ends are left the way the car came,
+// and a car without any allowed neighbour holds its position.
+func (c *Car) randomDrive() {
+	c.mu.Lock()
+	current := c.CarInfo.Position
+	c.mu.Unlock()
+
+	reverse := -1
+	for _, moveDirection := range rand.Perm(4) { // 0 (up), 1 (down), 2 (left), 3 (right)
+		if !c.canEnter(neighbour(current, moveDirection)) {
+			continue // Skip moves off the grid and roads the vehicle must not use
+		}
+		if moveDirection == c.oppositeDirection() {
+			reverse = moveDirection // Only taken if nothing else is left
+			continue
 		}
+		c.moveTo(moveDirection, neighbour(current, moveDirection))
+		return
+	}
+	if reverse >= 0 {
+		c.moveTo(reverse, neighbour(current, reverse))
+	}
+}
 
-		// If the new position is valid and not reversing the last move, update the position and break the loop
-		c.LastMoveDir = moveDirection
-		c.mu.Lock()
-		c.CarInfo.Position = newPosition
-		c.mu.Unlock()
-		break
+// neighbour returns the cell next to pos in the given move direction.
+func neighbour(pos *api.Coordinate, moveDirection int) *api.Coordinate {
+	next := &api.Coordinate{X: pos.X, Y: pos.Y}
+	switch moveDirection {
+	case 0:
+		next.Y -= 1 // up
+	case 1:
+		next.Y += 1 // down
+	case 2:
+		next.X -= 1 // left
+	case 3:
+		next.X += 1 // right
 	}
+	return next
+}
+
+func (c *Car) moveTo(moveDirection int, newPosition *api.Coordinate) {
+	c.LastMoveDir = moveDirection
+	c.mu.Lock()
+	c.CarInfo.Position = newPosition
+	c.mu.Unlock()
 }
 
 func (c *Car) oppositeDirection() int {
@@ -118,6 +224,9 @@ func (c *Car) calculateCost(pos *api.Coordinate) float64 {
 
 	for _, peer := range c.peers {
 		distance := c.manhattanDistance(pos, peer.Position)
+		if distance == 0 {
+			return math.Inf(1) // Cell is occupied by a peer
+		}
 		cost += 1 / distance
 	}
 	return cost
@@ -136,14 +245,14 @@ func (c *Car) advancedDrive() {
 	minCost := math.MaxFloat64
 
 	for _, pos := range potentialPositions {
-		// Check if the position is within bounds
-		if pos.X < 0 || pos.X >= int32(c.GridWidth) || pos.Y < 0 || pos.Y >= int32(c.GridHeight) {
+		// Check if the position is within bounds and on an allowed road
+		if !c.canEnter(pos) {
 			continue
 		}
 
 		cost := c.calculateCost(pos)
-		fmt.Println(cost)
-		if cost < minCost {
+		c.logger("drive").Debug("Repulsive cost", "position", pos, "cost", cost)
+		if bestPosition == nil || cost < minCost {
 			minCost = cost
 			bestPosition = pos
 		}
@@ -156,38 +265,176 @@ func (c *Car) advancedDrive() {
 	}
 }
 
-func (c *Car) driveRoute() {
-	if len(c.CarInfo.Route.Coordinates) == 0 {
-		return
+// predictPath forecasts the positions of a peer for the next cells, starting
+// with its current position. Peers on an active route are expected to follow
+// it, all others to stay where they are.
+func predictPath(peer *api.CarInfo, ticks int) []*api.Coordinate {
+	forecast := []*api.Coordinate{peer.Position}
+
+	if peer.ActiveRoute && peer.Route != nil && len(peer.Route.Coordinates) > 0 {
+		route := peer.Route.Coordinates
+		next := -1
+		for i, coord := range route {
+			if coord.X == peer.Position.X && coord.Y == peer.Position.Y {
+				next = i + 1
+				break
+			}
+		}
+
+		var ahead []*api.Coordinate
+		if next >= 0 {
+			ahead = route[next:]
+		} else {
+			// Still on the way to the route start
+			toStart := utils.CalculatePath(peer.Position, route[0], peer.Route)
+			if len(toStart) > 0 {
+				ahead = append(toStart[1:], route[1:]...)
+			}
+		}
+		for _, coord := range ahead {
+			if len(forecast) > ticks {
+				break
+			}
+			forecast = append(forecast, coord)
+		}
 	}
 
-	// Drive to the first position in the route
-	toRouteStart := utils.CalculatePath(c.CarInfo.Position, c.CarInfo.Route.Coordinates[0], c.CarInfo.Route)
-	fmt.Println("Path to route start:", toRouteStart)
+	for len(forecast) <= ticks {
+		forecast = append(forecast, forecast[len(forecast)-1])
+	}
+	return forecast
+}
 
-	for _, coord := range toRouteStart {
-		c.mu.Lock()
-		c.CarInfo.Position = coord
-		fmt.Printf("Driving to route start: X: %d, Y: %d\n", c.CarInfo.Position.X, c.CarInfo.Position.Y)
-		c.mu.Unlock()
-		c.updateCoordinator()
-		time.Sleep(1 * time.Second)
+// predictedCost scores pos against the forecasts of all peers. Cells occupied
+// now or in the next tick are impossible, later conflicts weigh less the
+// further they lie in the future.
+func predictedCost(pos *api.Coordinate, forecasts [][]*api.Coordinate) float64 {
+	cost := 0.0
+	for _, forecast := range forecasts {
+		for tick, predicted := range forecast {
+			weight := 1 / float64(tick+1)
+			distance := utils.Distance(pos, predicted)
+			if distance == 0 {
+				if tick <= 1 {
+					return math.Inf(1)
+				}
+				cost += 2 * weight
+				continue
+			}
+			cost += weight / distance
+		}
 	}
+	return cost
+}
 
-	for _, coord := range c.CarInfo.Route.Coordinates {
-		c.mu.Lock()
-		c.CarInfo.Position = coord
-		fmt.Printf("Driving to route position: X: %d, Y: %d\n", c.CarInfo.Position.X, c.CarInfo.Position.Y)
-		c.mu.Unlock()
-		c.updateCoordinator()
-		time.Sleep(1 * time.Second)
+// predictiveDrive moves to the neighbouring cell with the fewest predicted
+// conflicts with the announced routes of the peers.
+func (c *Car) predictiveDrive() {
+	c.peerMutex.Lock()
+	forecasts := make([][]*api.Coordinate, 0, len(c.peers))
+	for _, peer := range c.peers {
+		if peer.Position != nil {
+			forecasts = append(forecasts, predictPath(peer, predictionHorizon))
+		}
 	}
+	c.peerMutex.Unlock()
 
-	fmt.Println("Route completed. Checking for new route or switching to random drive after 1 seconds.")
-	time.Sleep(1 * time.Second)
 	c.mu.Lock()
-	c.CarInfo.ActiveRoute = false // Route is completed, switch to random drive if no new route
-	fmt.Printf("Set Active Route to false")
+	current := c.CarInfo.Position
+	c.mu.Unlock()
+
+	potentialPositions := []*api.Coordinate{
+		{X: current.X, Y: current.Y - 1}, // up
+		{X: current.X, Y: current.Y + 1}, // down
+		{X: current.X - 1, Y: current.Y}, // left
+		{X: current.X + 1, Y: current.Y}, // right
+		{X: current.X, Y: current.Y},     // hold
+	}
+
+	bestPosition := potentialPositions[len(potentialPositions)-1]
+	minCost := math.Inf(1)
 
+	for _, pos := range potentialPositions {
+		// Check if the position is within bounds and on an allowed road
+		if !c.canEnter(pos) {
+			continue
+		}
+
+		cost := predictedCost(pos, forecasts)
+		if cost < minCost {
+			minCost = cost
+			bestPosition = pos
+		}
+	}
+
+	c.mu.Lock()
+	c.CarInfo.Position = bestPosition
 	c.mu.Unlock()
 }
+
+// stepItinerary serves the stops at the current cell and moves one cell
+// towards the next stop. The itinerary may be replaced while driving, so the
+// path is looked up again for every cell.
+func (c *Car) stepItinerary() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.CarInfo.Itinerary) > 0 {
+		stop := c.CarInfo.Itinerary[0]
+		if c.CarInfo.Position.X != stop.Position.X || c.CarInfo.Position.Y != stop.Position.Y {
+			break
+		}
+		c.completeStop(stop)
+		c.leg = nil
+	}
+
+	if len(c.CarInfo.Itinerary) == 0 {
+		c.CarInfo.ActiveRoute = false // Itinerary is completed, switch back to the behavior if no new trip
+		c.CarInfo.CurrentLeg = nil
+		c.CarInfo.Route = &api.Route{}
+		c.idleSince = time.Now()
+		c.leg = nil
+		c.logger("itinerary").Info("Itinerary completed")
+		return
+	}
+
+	stop := c.CarInfo.Itinerary[0]
+	if c.leg != nil && stopKey(c.leg) != stopKey(stop) {
+		// The itinerary was replaced or cancelled mid-leg, continue from the current cell
+		c.logger("itinerary").Info("Leg preempted", logging.TripKey, c.leg.TripId, "stop", c.leg.Type, "next_trip", stop.TripId, "next_stop", stop.Type)
+	}
+	c.leg = stop
+	c.CarInfo.CurrentLeg = stop
+	if c.CarInfo.Energy <= 0 {
+		c.logger("itinerary").Warn("Out of energy, stopping on the itinerary", logging.TripKey, stop.TripId)
+		return
+	}
+
+	path := utils.CalculatePathFor(c.CarInfo.Position, stop.Position, c.vehicle)
+	if len(path) < 2 {
+		c.logger("itinerary").Warn("No road to stop", logging.TripKey, stop.TripId, "stop", stop.Type)
+		return
+	}
+	c.CarInfo.Route = &api.Route{Coordinates: path}
+	c.CarInfo.Position = path[1]
+	c.logger("itinerary").Debug("Driving to stop", logging.TripKey, stop.TripId, "stop", stop.Type, "x", c.CarInfo.Position.X, "y", c.CarInfo.Position.Y)
+}
+
+// completeStop picks up or drops off the passengers of the first stop and
+// removes it from the itinerary. Must be called with c.mu held.
+func (c *Car) completeStop(stop *api.Stop) {
+	switch stop.Type {
+	case api.StopType_PICKUP:
+		c.CarInfo.Passengers += stop.Passengers
+	case api.StopType_DROPOFF:
+		c.CarInfo.Passengers -= stop.Passengers
+	}
+	c.completedStops[stopKey(stop)] = true
+	c.CarInfo.Itinerary = c.CarInfo.Itinerary[1:]
+	c.itineraryChanged()
+	c.logger("itinerary").Info("Completed stop", logging.TripKey, stop.TripId, "stop", stop.Type, "passengers", c.CarInfo.Passengers)
+}
+
+func stopKey(stop *api.Stop) string {
+	return fmt.Sprintf("%s/%s", stop.TripId, stop.Type)
+}
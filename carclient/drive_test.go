@@ -0,0 +1,118 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/utils"
+	"math"
+	"testing"
+	"time"
+)
+
+// testCar returns a car of the vehicle type at pos whose coordinator is
+// never reached.
+func testCar(t *testing.T, vehicleType string, pos *api.Coordinate) *Car {
+	t.Helper()
+	vehicle, _ := utils.LookupVehicleType(vehicleType)
+	car := newCar("localhost:50001", "car-test", []string{"localhost:0"}, pos, "", 0, vehicle, int(vehicle.Seats))
+	if car == nil {
+		t.Fatal("failed to create car")
+	}
+	t.Cleanup(func() { car.Conn.Close() })
+	return car
+}
+
+func TestRandomDriveStaysOnAllowedRoads(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		vehicleType string
+		start       *api.Coordinate
+		lastMove    int
+		want        *api.Coordinate
+	}{
+		{"bus in the corner lanes holds", "bus", &api.Coordinate{X: 0, Y: 0}, -1, &api.Coordinate{X: 0, Y: 0}},
+		{"car inside the pedestrian zone holds", "car", &api.Coordinate{X: 10, Y: 10}, -1, &api.Coordinate{X: 10, Y: 10}},
+		// Lanes are closed to buses and the last move up came from (1,2)
+		{"bus takes the only street ahead", "bus", &api.Coordinate{X: 1, Y: 1}, 0, &api.Coordinate{X: 2, Y: 1}},
+	} {
+		car := testCar(t, c.vehicleType, c.start)
+		car.LastMoveDir = c.lastMove
+		done := make(chan struct{})
+		go func() {
+			car.randomDrive()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("%s: randomDrive did not return", c.name)
+		}
+		if pos := car.CarInfo.Position; pos.X != c.want.X || pos.Y != c.want.Y {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, pos)
+		}
+	}
+}
+
+func coords(points ...[2]int32) []*api.Coordinate {
+	var result []*api.Coordinate
+	for _, p := range points {
+		result = append(result, &api.Coordinate{X: p[0], Y: p[1]})
+	}
+	return result
+}
+
+func TestPredictPath(t *testing.T) {
+	route := &api.Route{Coordinates: coords([2]int32{2, 1}, [2]int32{3, 1}, [2]int32{4, 1}, [2]int32{5, 1})}
+	for _, c := range []struct {
+		name string
+		peer *api.CarInfo
+		want []*api.Coordinate
+	}{
+		{"peer without route stays", &api.CarInfo{Position: &api.Coordinate{X: 1, Y: 1}},
+			coords([2]int32{1, 1}, [2]int32{1, 1}, [2]int32{1, 1}, [2]int32{1, 1})},
+		{"standing peer with inactive route stays", &api.CarInfo{Position: &api.Coordinate{X: 3, Y: 1}, Speed: 0, Route: route},
+			coords([2]int32{3, 1}, [2]int32{3, 1}, [2]int32{3, 1}, [2]int32{3, 1})},
+		{"peer on its route follows it and waits at the end", &api.CarInfo{Position: &api.Coordinate{X: 3, Y: 1}, ActiveRoute: true, Route: route},
+			coords([2]int32{3, 1}, [2]int32{4, 1}, [2]int32{5, 1}, [2]int32{5, 1})},
+		{"peer before its route drives to the start first", &api.CarInfo{Position: &api.Coordinate{X: 1, Y: 1}, ActiveRoute: true, Route: route},
+			coords([2]int32{1, 1}, [2]int32{2, 1}, [2]int32{3, 1}, [2]int32{4, 1})},
+	} {
+		got := predictPath(c.peer, 3)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i].X != c.want[i].X || got[i].Y != c.want[i].Y {
+				t.Errorf("%s: tick %d at %v, want %v", c.name, i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestPredictedCost(t *testing.T) {
+	pos := &api.Coordinate{X: 5, Y: 5}
+	standing := coords([2]int32{5, 5}, [2]int32{5, 5}, [2]int32{5, 5})
+	arrivingNext := coords([2]int32{5, 6}, [2]int32{5, 5}, [2]int32{5, 4})
+	arrivingLater := coords([2]int32{5, 8}, [2]int32{5, 7}, [2]int32{5, 6}, [2]int32{5, 5})
+	near := coords([2]int32{5, 6}, [2]int32{5, 6})
+	far := coords([2]int32{5, 9}, [2]int32{5, 9})
+
+	// A peer standing on the cell, with zero distance and zero speed, makes
+	// it impossible instead of dividing by zero
+	if cost := predictedCost(pos, [][]*api.Coordinate{standing}); !math.IsInf(cost, 1) {
+		t.Errorf("occupied cell: got %v, want +Inf", cost)
+	}
+	if cost := predictedCost(pos, [][]*api.Coordinate{arrivingNext}); !math.IsInf(cost, 1) {
+		t.Errorf("cell entered in the next tick: got %v, want +Inf", cost)
+	}
+	later := predictedCost(pos, [][]*api.Coordinate{arrivingLater})
+	if math.IsInf(later, 0) || math.IsNaN(later) || later <= predictedCost(pos, [][]*api.Coordinate{far}) {
+		t.Errorf("later conflict: got %v, want a finite cost above a distant peer", later)
+	}
+	if predictedCost(pos, [][]*api.Coordinate{near}) <= predictedCost(pos, [][]*api.Coordinate{far}) {
+		t.Error("expected a nearer peer to cost more")
+	}
+	if cost := predictedCost(pos, nil); cost != 0 {
+		t.Errorf("no peers: got %v, want 0", cost)
+	}
+}
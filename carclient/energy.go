@@ -0,0 +1,76 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/utils"
+)
+
+// EnergyModel describes the battery of a car. All values are in energy units.
+type EnergyModel struct {
+	Capacity     float64 // energy of a full battery
+	PerCell      float64 // consumed per cell driven
+	IdleDrain    float64 // consumed per second without moving
+	ChargeRate   float64 // recharged per second at a charging station
+	LowThreshold float64 // fraction of the capacity below which the car goes charging
+}
+
+// setEnergyModel installs the battery model and starts with a full battery.
+func (c *Car) setEnergyModel(model EnergyModel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.energy = model
+	c.CarInfo.Energy = model.Capacity
+	c.CarInfo.EnergyCapacity = model.Capacity
+	c.CarInfo.ConsumptionPerCell = model.PerCell
+}
+
+// consumeEnergy books the energy for the cells entered and the time spent
+// standing during the last dt seconds. Must be called with c.mu held.
+func (c *Car) consumeEnergy(cells int, dt float64) {
+	switch {
+	case cells > 0:
+		c.CarInfo.Energy -= float64(cells) * c.energy.PerCell
+	case c.CarInfo.Speed == 0 && c.CarInfo.State != api.CarState_CHARGING:
+		c.CarInfo.Energy -= c.energy.IdleDrain * dt
+	}
+	if c.CarInfo.Energy < 0 {
+		c.CarInfo.Energy = 0
+	}
+}
+
+// lowEnergy reports whether the car should go charging. Must be called with c.mu held.
+func (c *Car) lowEnergy() bool {
+	return c.CarInfo.Energy < c.energy.LowThreshold*c.energy.Capacity
+}
+
+func (c *Car) atChargingStation() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return utils.ChargingStationAt(c.CarInfo.Position) >= 0
+}
+
+// charge drives to the nearest charging station and recharges until the
+// battery is full. The drive loop selects it when the energy runs low; while
+// the car stands at the station, Step is called once per second.
+type charge struct{}
+
+func (charge) Step(c *Car) {
+	if !c.atChargingStation() {
+		c.mu.Lock()
+		station := utils.NearestChargingStation(c.CarInfo.Position)
+		c.mu.Unlock()
+		c.stepTowards(station)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.CarInfo.Energy += c.energy.ChargeRate
+	if c.CarInfo.Energy >= c.energy.Capacity {
+		c.CarInfo.Energy = c.energy.Capacity
+		c.charging = false
+		c.logger("energy").Info("Battery fully charged")
+	}
+}
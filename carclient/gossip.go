@@ -0,0 +1,284 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"context"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	gossipInterval    = 1 * time.Second
+	probeTimeout      = 500 * time.Millisecond
+	indirectProbes    = 3
+	suspicionTimeout  = 5 * time.Second
+	deadMemberTimeout = 30 * time.Second
+)
+
+type member struct {
+	info        *api.CarInfo
+	incarnation uint64
+	state       api.MemberState
+	stateSince  time.Time
+}
+
+// membership is the local view of the SWIM-style gossip group.
+type membership struct {
+	mu          sync.Mutex
+	self        string
+	incarnation uint64
+	members     map[string]*member
+	log         *slog.Logger
+}
+
+func newMembership(self string, seeds []string) *membership {
+	m := &membership{
+		self:    self,
+		members: make(map[string]*member),
+		log:     logging.Component("gossip").With(logging.CarKey, self),
+	}
+	for _, seed := range seeds {
+		if seed == "" || seed == self {
+			continue
+		}
+		m.members[seed] = &member{
+			info:       &api.CarInfo{Identifier: seed},
+			state:      api.MemberState_ALIVE,
+			stateSince: time.Now(),
+		}
+	}
+	return m
+}
+
+func parseSeeds(seeds string) []string {
+	var result []string
+	for _, seed := range strings.Split(seeds, ",") {
+		seed = strings.TrimSpace(seed)
+		if seed != "" {
+			result = append(result, seed)
+		}
+	}
+	return result
+}
+
+// merge applies a received member entry to the local view. Higher incarnations
+// win, and for equal incarnations DEAD overrides SUSPECT overrides ALIVE.
+func (m *membership) merge(in *api.Member) {
+	if in.CarInfo == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := in.CarInfo.Identifier
+	if id == m.self {
+		// Refute rumours about ourselves by bumping our incarnation
+		if in.State != api.MemberState_ALIVE && in.Incarnation >= m.incarnation {
+			m.incarnation = in.Incarnation + 1
+		}
+		return
+	}
+
+	existing, ok := m.members[id]
+	if !ok {
+		if in.State == api.MemberState_DEAD {
+			return
+		}
+		m.members[id] = &member{
+			info:        in.CarInfo,
+			incarnation: in.Incarnation,
+			state:       in.State,
+			stateSince:  time.Now(),
+		}
+		m.log.Info("New member", "member", id)
+		return
+	}
+
+	if in.Incarnation > existing.incarnation || (in.Incarnation == existing.incarnation && in.State > existing.state) {
+		if in.State != existing.state {
+			existing.stateSince = time.Now()
+		}
+		existing.incarnation = in.Incarnation
+		existing.state = in.State
+	}
+	// Positions are only ever fresher from newer incarnations or the same one
+	if in.Incarnation >= existing.incarnation {
+		existing.info = in.CarInfo
+	}
+}
+
+// digest returns our own entry plus every known member for piggybacking.
+func (m *membership) digest(self *api.CarInfo) *api.GossipMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	msg := &api.GossipMessage{Sender: m.self}
+	msg.Members = append(msg.Members, &api.Member{CarInfo: self, Incarnation: m.incarnation, State: api.MemberState_ALIVE})
+	for _, mem := range m.members {
+		msg.Members = append(msg.Members, &api.Member{CarInfo: mem.info, Incarnation: mem.incarnation, State: mem.state})
+	}
+	return msg
+}
+
+func (m *membership) setState(id string, state api.MemberState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mem, ok := m.members[id]
+	if !ok || mem.state == state {
+		return
+	}
+	// A member that answered is alive again, but only a newer incarnation may revive the dead
+	if state == api.MemberState_ALIVE && mem.state == api.MemberState_DEAD {
+		return
+	}
+	mem.state = state
+	mem.stateSince = time.Now()
+	m.log.Info("Member state changed", "member", id, "state", state)
+}
+
+// probeTargets returns a random live member to probe and up to k helpers for indirect probes.
+func (m *membership) probeTargets(k int) (string, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var candidates []string
+	for id, mem := range m.members {
+		if mem.state != api.MemberState_DEAD {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+	helpers := candidates[1:]
+	if len(helpers) > k {
+		helpers = helpers[:k]
+	}
+	return candidates[0], helpers
+}
+
+// expire promotes timed out suspects to DEAD and forgets long dead members.
+func (m *membership) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, mem := range m.members {
+		switch {
+		case mem.state == api.MemberState_SUSPECT && time.Since(mem.stateSince) > suspicionTimeout:
+			mem.state = api.MemberState_DEAD
+			mem.stateSince = time.Now()
+			m.log.Warn("Member declared dead", "member", id)
+		case mem.state == api.MemberState_DEAD && time.Since(mem.stateSince) > deadMemberTimeout:
+			delete(m.members, id)
+		}
+	}
+}
+
+// view splits the members into live ones with a known position and dead ones.
+func (m *membership) view() (alive []*api.CarInfo, dead []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, mem := range m.members {
+		if mem.state == api.MemberState_DEAD {
+			dead = append(dead, id)
+		} else if mem.info.Position != nil {
+			alive = append(alive, mem.info)
+		}
+	}
+	return alive, dead
+}
+
+func (c *Car) selfInfo() *api.CarInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return proto.Clone(c.CarInfo).(*api.CarInfo)
+}
+
+// gossipWith exchanges digests with the given address and merges its answer.
+func (c *Car) gossipWith(address string) error {
+	conn, err := c.peerConn(address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	client := api.NewCarClientServiceClient(conn)
+	resp, err := client.Gossip(ctx, c.gossip.digest(c.selfInfo()))
+	if err != nil {
+		return err
+	}
+	for _, mem := range resp.Members {
+		c.gossip.merge(mem)
+	}
+	return nil
+}
+
+// indirectProbe asks the helpers to ping target on our behalf.
+func (c *Car) indirectProbe(target string, helpers []string) bool {
+	acks := make(chan bool, len(helpers))
+	for _, helper := range helpers {
+		go func(helper string) {
+			conn, err := c.peerConn(helper)
+			if err != nil {
+				acks <- false
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*probeTimeout)
+			defer cancel()
+
+			resp, err := api.NewCarClientServiceClient(conn).PingReq(ctx, &api.PingRequest{Target: target})
+			acks <- err == nil && resp.Ack
+		}(helper)
+	}
+	for range helpers {
+		if <-acks {
+			return true
+		}
+	}
+	return false
+}
+
+// runGossip replaces the port scan of discoverPeers when gossip mode is enabled.
+func (c *Car) runGossip() {
+	ticker := time.NewTicker(gossipInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.gossip.expire()
+
+		target, helpers := c.gossip.probeTargets(indirectProbes)
+		if target != "" {
+			if err := c.gossipWith(target); err == nil {
+				c.gossip.setState(target, api.MemberState_ALIVE)
+			} else if c.indirectProbe(target, helpers) {
+				c.gossip.setState(target, api.MemberState_ALIVE)
+			} else {
+				c.gossip.setState(target, api.MemberState_SUSPECT)
+			}
+		}
+
+		// Membership comes from gossip, positions are pushed by the peer subscriptions
+		alive, dead := c.gossip.view()
+		for _, info := range alive {
+			if c.inRange(info.Position) {
+				c.addPeer(info)
+			}
+		}
+		for _, id := range dead {
+			c.removePeer(id)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"testing"
+	"time"
+)
+
+const peer = "localhost:50002"
+
+// knownMember returns a view of self which knows peer in the given state.
+func knownMember(incarnation uint64, state api.MemberState) *membership {
+	m := newMembership("localhost:50001", nil)
+	m.members[peer] = &member{
+		info:        &api.CarInfo{Identifier: peer, Position: &api.Coordinate{X: 1, Y: 1}},
+		incarnation: incarnation,
+		state:       state,
+		stateSince:  time.Now(),
+	}
+	return m
+}
+
+func rumour(incarnation uint64, state api.MemberState, x int32) *api.Member {
+	return &api.Member{
+		CarInfo:     &api.CarInfo{Identifier: peer, Position: &api.Coordinate{X: x, Y: 1}},
+		Incarnation: incarnation,
+		State:       state,
+	}
+}
+
+func TestMergePrecedence(t *testing.T) {
+	for _, c := range []struct {
+		name             string
+		incarnation      uint64
+		state            api.MemberState
+		in               *api.Member
+		wantIncarnation  uint64
+		wantState        api.MemberState
+		wantPositionFrom int32 // X of the position kept
+	}{
+		{"suspicion of the same incarnation", 1, api.MemberState_ALIVE, rumour(1, api.MemberState_SUSPECT, 2), 1, api.MemberState_SUSPECT, 2},
+		{"death of the same incarnation", 1, api.MemberState_SUSPECT, rumour(1, api.MemberState_DEAD, 2), 1, api.MemberState_DEAD, 2},
+		{"alive does not clear a suspicion of the same incarnation", 1, api.MemberState_SUSPECT, rumour(1, api.MemberState_ALIVE, 2), 1, api.MemberState_SUSPECT, 2},
+		{"newer incarnation refutes a suspicion", 1, api.MemberState_SUSPECT, rumour(2, api.MemberState_ALIVE, 2), 2, api.MemberState_ALIVE, 2},
+		{"newer incarnation revives the dead", 1, api.MemberState_DEAD, rumour(2, api.MemberState_ALIVE, 2), 2, api.MemberState_ALIVE, 2},
+		{"older incarnation is ignored", 3, api.MemberState_ALIVE, rumour(2, api.MemberState_DEAD, 2), 3, api.MemberState_ALIVE, 1},
+	} {
+		m := knownMember(c.incarnation, c.state)
+		m.merge(c.in)
+		got := m.members[peer]
+		if got.incarnation != c.wantIncarnation || got.state != c.wantState {
+			t.Errorf("%s: got incarnation %d %v, want %d %v", c.name, got.incarnation, got.state, c.wantIncarnation, c.wantState)
+		}
+		if got.info.Position.X != c.wantPositionFrom {
+			t.Errorf("%s: kept the position %v", c.name, got.info.Position)
+		}
+	}
+}
+
+func TestMergeOfUnknownMembersAndSelf(t *testing.T) {
+	m := newMembership("localhost:50001", nil)
+	m.merge(rumour(1, api.MemberState_DEAD, 2))
+	if _, ok := m.members[peer]; ok {
+		t.Error("dead unknown member was added")
+	}
+	m.merge(rumour(1, api.MemberState_ALIVE, 2))
+	if mem, ok := m.members[peer]; !ok || mem.state != api.MemberState_ALIVE {
+		t.Error("expected a live unknown member to be added")
+	}
+
+	// Rumours about ourselves are refuted with a higher incarnation
+	for _, c := range []struct {
+		name            string
+		in              *api.Member
+		wantIncarnation uint64
+	}{
+		{"suspicion of the current incarnation", &api.Member{CarInfo: &api.CarInfo{Identifier: m.self}, Incarnation: 0, State: api.MemberState_SUSPECT}, 1},
+		{"death of a later incarnation", &api.Member{CarInfo: &api.CarInfo{Identifier: m.self}, Incarnation: 4, State: api.MemberState_DEAD}, 5},
+		{"suspicion of an older incarnation", &api.Member{CarInfo: &api.CarInfo{Identifier: m.self}, Incarnation: 2, State: api.MemberState_SUSPECT}, 5},
+		{"alive", &api.Member{CarInfo: &api.CarInfo{Identifier: m.self}, Incarnation: 7, State: api.MemberState_ALIVE}, 5},
+	} {
+		m.merge(c.in)
+		if m.incarnation != c.wantIncarnation {
+			t.Errorf("%s: incarnation %d, want %d", c.name, m.incarnation, c.wantIncarnation)
+		}
+		if _, ok := m.members[m.self]; ok {
+			t.Fatalf("%s: added itself as member", c.name)
+		}
+	}
+}
+
+func TestExpire(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		state     api.MemberState
+		since     time.Duration
+		wantState api.MemberState
+		wantGone  bool
+	}{
+		{"fresh suspect stays suspect", api.MemberState_SUSPECT, time.Second, api.MemberState_SUSPECT, false},
+		{"timed out suspect is declared dead", api.MemberState_SUSPECT, suspicionTimeout + time.Second, api.MemberState_DEAD, false},
+		{"long dead member is forgotten", api.MemberState_DEAD, deadMemberTimeout + time.Second, api.MemberState_DEAD, true},
+		{"alive member is kept however old", api.MemberState_ALIVE, deadMemberTimeout + time.Second, api.MemberState_ALIVE, false},
+	} {
+		m := knownMember(1, c.state)
+		m.members[peer].stateSince = time.Now().Add(-c.since)
+		m.expire()
+		got, ok := m.members[peer]
+		if ok == c.wantGone {
+			t.Errorf("%s: member kept %v, want %v", c.name, ok, !c.wantGone)
+			continue
+		}
+		if ok && got.state != c.wantState {
+			t.Errorf("%s: got %v, want %v", c.name, got.state, c.wantState)
+		}
+	}
+
+	// A probe answer does not revive a dead member, only a newer incarnation does
+	m := knownMember(1, api.MemberState_DEAD)
+	m.setState(peer, api.MemberState_ALIVE)
+	if m.members[peer].state != api.MemberState_DEAD {
+		t.Error("dead member was revived without a newer incarnation")
+	}
+}
@@ -0,0 +1,169 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// itineraryChanged bumps the version after every change of the itinerary
+// and updates the reported leg. Must be called with c.mu held.
+func (c *Car) itineraryChanged() {
+	c.CarInfo.ItineraryVersion++
+	c.CarInfo.ActiveRoute = len(c.CarInfo.Itinerary) > 0
+	c.CarInfo.CurrentLeg = nil
+	if c.CarInfo.ActiveRoute {
+		c.CarInfo.CurrentLeg = c.CarInfo.Itinerary[0]
+		c.CarInfo.State = api.CarState_ON_ROUTE
+		c.stopped = false
+	}
+}
+
+// itineraryAck reports which leg is executing. Must be called with c.mu held.
+func (c *Car) itineraryAck(message string) *api.ItineraryAck {
+	return &api.ItineraryAck{
+		Message:    message,
+		Version:    c.CarInfo.ItineraryVersion,
+		CurrentLeg: c.CarInfo.CurrentLeg,
+		Stops:      c.CarInfo.Itinerary,
+	}
+}
+
+// pendingStops drops stops which were served since the sender built its list.
+// Must be called with c.mu held.
+func (c *Car) pendingStops(stops []*api.Stop) []*api.Stop {
+	var pending []*api.Stop
+	for _, stop := range stops {
+		if !c.completedStops[stopKey(stop)] {
+			pending = append(pending, stop)
+		}
+	}
+	return pending
+}
+
+// replaceItinerary swaps the whole itinerary, preempting the current leg.
+func (c *Car) replaceItinerary(stops []*api.Stop, expectedVersion uint64) (*api.ItineraryAck, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if expectedVersion != 0 && expectedVersion != c.CarInfo.ItineraryVersion {
+		return nil, status.Errorf(codes.Aborted, "itinerary version is %d, expected %d", c.CarInfo.ItineraryVersion, expectedVersion)
+	}
+
+	c.CarInfo.Itinerary = c.pendingStops(stops)
+	c.itineraryChanged()
+	c.logger("itinerary").Info("Itinerary replaced", "stops", len(c.CarInfo.Itinerary), "version", c.CarInfo.ItineraryVersion)
+	return c.itineraryAck("Itinerary replaced"), nil
+}
+
+// appendItinerary queues stops behind the current itinerary.
+func (c *Car) appendItinerary(stops []*api.Stop) *api.ItineraryAck {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.CarInfo.Itinerary = append(c.CarInfo.Itinerary, c.pendingStops(stops)...)
+	c.itineraryChanged()
+	c.logger("itinerary").Info("Itinerary extended", "stops", len(c.CarInfo.Itinerary), "version", c.CarInfo.ItineraryVersion)
+	return c.itineraryAck("Itinerary appended")
+}
+
+// cancelItinerary removes the stops of the given trips, or all stops if no
+// trip is given. Passengers of cancelled trips leave the car right here.
+func (c *Car) cancelItinerary(tripIDs []string) *api.ItineraryAck {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cancelled := make(map[string]bool)
+	for _, id := range tripIDs {
+		cancelled[id] = true
+	}
+
+	waiting := make(map[string]bool) // trips whose passengers are not picked up yet
+	for _, stop := range c.CarInfo.Itinerary {
+		if stop.Type == api.StopType_PICKUP {
+			waiting[stop.TripId] = true
+		}
+	}
+
+	var kept []*api.Stop
+	for _, stop := range c.CarInfo.Itinerary {
+		if len(tripIDs) > 0 && !cancelled[stop.TripId] {
+			kept = append(kept, stop)
+			continue
+		}
+		if stop.Type == api.StopType_DROPOFF && !waiting[stop.TripId] {
+			// Passengers are on board, let them out at the current position
+			c.CarInfo.Passengers -= stop.Passengers
+		}
+		if stop.Type == api.StopType_DROPOFF {
+			c.CarInfo.AbortedTrips = append(c.CarInfo.AbortedTrips, stop.TripId)
+		}
+		c.logger("itinerary").Info("Cancelled stop", logging.TripKey, stop.TripId, "stop", stop.Type)
+	}
+
+	c.CarInfo.Itinerary = kept
+	c.itineraryChanged()
+	return c.itineraryAck(fmt.Sprintf("Itinerary cancelled, %d stops left", len(kept)))
+}
+
+// cancelRoute aborts the given trips, or all trips, and stops the car at the
+// next safe cell if nothing is left to drive.
+func (c *Car) cancelRoute(tripIDs []string) *api.ItineraryAck {
+	ack := c.cancelItinerary(tripIDs)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.CarInfo.Itinerary) == 0 {
+		c.stopped = true
+		c.CarInfo.Route = &api.Route{}
+		c.logger("itinerary").Info("Route aborted, stopping at the next safe cell")
+	}
+	return ack
+}
+
+// recall aborts all trips and sends the car back to its depot.
+func (c *Car) recall() (*api.ItineraryAck, error) {
+	ack := c.cancelItinerary(nil)
+	if err := c.setBehavior("returnToDepot"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.commanded = false
+	c.mu.Unlock()
+	ack.Message = "Recalled to depot"
+	return ack, nil
+}
+
+// safeStop holds the car, but leaves a cell which is shared with a peer first.
+type safeStop struct{}
+
+func (safeStop) Step(c *Car) {
+	c.mu.Lock()
+	current := c.CarInfo.Position
+	c.mu.Unlock()
+
+	if c.calculateCost(current) != math.Inf(1) {
+		return
+	}
+	for _, pos := range []*api.Coordinate{
+		{X: current.X, Y: current.Y - 1},
+		{X: current.X, Y: current.Y + 1},
+		{X: current.X - 1, Y: current.Y},
+		{X: current.X + 1, Y: current.Y},
+	} {
+		if !c.canEnter(pos) {
+			continue
+		}
+		if c.calculateCost(pos) != math.Inf(1) {
+			c.mu.Lock()
+			c.CarInfo.Position = pos
+			c.mu.Unlock()
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package carclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+var (
+	carMoves = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "car_moves_total",
+		Help: "Cells the car entered.",
+	})
+	carRPCErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "car_rpc_errors_total",
+		Help: "Failed outgoing gRPC calls to the coordinator and peers.",
+	}, []string{"method"})
+)
+
+// countUnaryErrors counts failed calls per method.
+func countUnaryErrors(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		carRPCErrors.WithLabelValues(method).Inc()
+	}
+	return err
+}
+
+// countStreamErrors counts streams which could not be opened per method.
+func countStreamErrors(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		carRPCErrors.WithLabelValues(method).Inc()
+	}
+	return stream, err
+}
+
+// serveMetrics serves the Prometheus metrics of the car on address under /metrics.
+func (c *Car) serveMetrics(address string) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "car_peers",
+		Help: "Peers the car currently tracks.",
+	}, func() float64 {
+		c.peerMutex.Lock()
+		defer c.peerMutex.Unlock()
+		return float64(len(c.peers))
+	})
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "car_energy",
+		Help: "Remaining battery energy.",
+	}, func() float64 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.CarInfo.Energy
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	c.logger("metrics").Info("Serving metrics", "address", address+"/metrics")
+	if err := http.ListenAndServe(address, mux); err != nil {
+		c.logger("metrics").Error("Failed to serve metrics", "err", err)
+	}
+}
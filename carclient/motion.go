@@ -0,0 +1,116 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"math"
+)
+
+// Motion describes how fast a car drives. Position in CarInfo is the cell the
+// car is heading to; from and progress tell how far along the edge it is.
+type Motion struct {
+	MaxSpeed     float64 // cells per second
+	Acceleration float64 // cells per second squared, 0 = instant
+	from         *api.Coordinate
+	progress     float64 // driven fraction of the current edge
+}
+
+// setMotion installs the speed limits and starts standing still.
+func (c *Car) setMotion(model Motion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.motion = model
+	c.motion.from = c.CarInfo.Position
+	c.CarInfo.MaxSpeed = model.MaxSpeed
+	c.updateExactPosition()
+}
+
+// onEdge reports whether the car is between two cells. Must be called with c.mu held.
+func (c *Car) onEdge() bool {
+	from, to := c.motion.from, c.CarInfo.Position
+	return from != nil && (from.X != to.X || from.Y != to.Y)
+}
+
+// accelerate speeds up towards the max speed and advances along the current
+// edge. Must be called with c.mu held.
+func (c *Car) accelerate(dt float64) {
+	speed := c.motion.MaxSpeed
+	if c.motion.Acceleration > 0 {
+		speed = math.Min(c.CarInfo.Speed+c.motion.Acceleration*dt, c.motion.MaxSpeed)
+	}
+	c.CarInfo.Speed = speed
+	c.motion.progress += speed * dt
+}
+
+// standStill ends the movement at the current cell. Must be called with c.mu held.
+func (c *Car) standStill() {
+	c.motion.from = c.CarInfo.Position
+	c.motion.progress = 0
+	c.CarInfo.Speed = 0
+}
+
+// updateExactPosition interpolates the continuous position and heading on the
+// current edge. Must be called with c.mu held.
+func (c *Car) updateExactPosition() {
+	to := c.CarInfo.Position
+	from := c.motion.from
+	if from == nil {
+		from = to
+	}
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	c.CarInfo.ExactPosition = &api.Point{
+		X: float64(from.X) + dx*c.motion.progress,
+		Y: float64(from.Y) + dy*c.motion.progress,
+	}
+	if dx != 0 || dy != 0 {
+		c.CarInfo.Heading = math.Mod(math.Atan2(dy, dx)*180/math.Pi+360, 360)
+	}
+}
+
+// move advances the car by dt. Whenever a cell is reached, and for a standing
+// car whenever decide is set, the behavior picks the next cell. It returns the
+// number of cells entered and the behavior stepped last, if any.
+func (c *Car) move(dt float64, decide bool) (int, Behavior) {
+	c.mu.Lock()
+	if c.onEdge() {
+		c.accelerate(dt)
+	}
+	c.mu.Unlock()
+
+	cells := 0
+	var stepped Behavior
+	for {
+		c.mu.Lock()
+		if c.onEdge() {
+			if c.motion.progress < 1 {
+				break
+			}
+			// Arrived at the cell, keep the leftover progress for the next edge
+			c.motion.progress--
+			c.motion.from = c.CarInfo.Position
+		} else if !decide {
+			break
+		}
+		decide = false
+		behavior := c.currentBehavior()
+		from := c.CarInfo.Position
+		c.mu.Unlock()
+
+		behavior.Step(c)
+		stepped = behavior
+
+		c.mu.Lock()
+		c.updateState(behavior)
+		to := c.CarInfo.Position
+		if to.X == from.X && to.Y == from.Y {
+			c.standStill()
+			break
+		}
+		c.motion.from = from
+		cells++
+		c.mu.Unlock()
+	}
+	c.updateExactPosition()
+	c.mu.Unlock()
+	return cells, stepped
+}
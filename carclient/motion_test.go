@@ -0,0 +1,75 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"testing"
+)
+
+// drivingCar returns an idle car at (1,1) with a full battery, sent to (1,5).
+func drivingCar(t *testing.T, motion Motion) *Car {
+	t.Helper()
+	car := testCar(t, "car", &api.Coordinate{X: 1, Y: 1})
+	car.CarInfo.Energy = 100
+	car.setMotion(motion)
+	if err := car.setBehavior("idle"); err != nil {
+		t.Fatal(err)
+	}
+	if err := car.reposition(&api.Coordinate{X: 1, Y: 5}); err != nil {
+		t.Fatal(err)
+	}
+	return car
+}
+
+func TestMoveAcceleratesWithinTheLimits(t *testing.T) {
+	motion := Motion{MaxSpeed: 2, Acceleration: 4}
+	car := drivingCar(t, motion)
+	const dt = 0.25
+
+	previous := 0.0
+	reachedMax := false
+	for tick := 0; tick < 40; tick++ {
+		car.move(dt, true)
+		speed := car.CarInfo.Speed
+		if speed > motion.MaxSpeed {
+			t.Fatalf("tick %d: speed %v above the max speed", tick, speed)
+		}
+		if speed > previous+motion.Acceleration*dt+1e-9 {
+			t.Fatalf("tick %d: speed rose from %v to %v, more than the acceleration allows", tick, previous, speed)
+		}
+		reachedMax = reachedMax || speed == motion.MaxSpeed
+		previous = speed
+	}
+	if !reachedMax {
+		t.Error("car never reached its max speed")
+	}
+}
+
+func TestMoveEntersSeveralCellsPerTickAndStopsAtTheTarget(t *testing.T) {
+	car := drivingCar(t, Motion{MaxSpeed: 8}) // Instant acceleration
+
+	// Standing still, the first tick only starts the first edge
+	if cells, _ := car.move(0.5, true); cells != 1 {
+		t.Fatalf("expected the first edge to be started, got %d cells", cells)
+	}
+	// 8 cells per second for half a second cover the remaining 3 cells with
+	// speed to spare, which is dropped at the target
+	cells, _ := car.move(0.5, false)
+	if cells != 3 {
+		t.Errorf("expected the remaining 3 cells in one tick, got %d", cells)
+	}
+	pos := car.CarInfo.Position
+	if pos.X != 1 || pos.Y != 5 {
+		t.Fatalf("expected the car at the target (1,5), got %v", pos)
+	}
+	if car.CarInfo.Speed != 0 || car.motion.progress != 0 || car.onEdge() {
+		t.Errorf("expected the car to stand at the target, speed %v, progress %v", car.CarInfo.Speed, car.motion.progress)
+	}
+	if exact := car.CarInfo.ExactPosition; exact.X != 1 || exact.Y != 5 {
+		t.Errorf("expected the exact position at the target, got %v", exact)
+	}
+
+	// Without a new target the car stays
+	if cells, _ := car.move(0.5, true); cells != 0 || car.CarInfo.Position.Y != 5 {
+		t.Errorf("expected the car to stay at the target, moved %d cells to %v", cells, car.CarInfo.Position)
+	}
+}
@@ -0,0 +1,179 @@
+package carclient
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// peerConn returns the pooled long-lived connection to address, dialing it on first use.
+func (c *Car) peerConn(address string) (*grpc.ClientConn, error) {
+	c.connMutex.Lock()
+	defer c.connMutex.Unlock()
+
+	if conn, ok := c.peerConns[address]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address, security.DialOption(),
+		grpc.WithUnaryInterceptor(countUnaryErrors), grpc.WithStreamInterceptor(countStreamErrors))
+	if err != nil {
+		return nil, err
+	}
+	c.peerConns[address] = conn
+	return conn, nil
+}
+
+func (c *Car) closePeerConn(address string) {
+	c.connMutex.Lock()
+	defer c.connMutex.Unlock()
+
+	if conn, ok := c.peerConns[address]; ok {
+		conn.Close()
+		delete(c.peerConns, address)
+	}
+}
+
+// addPeer registers a discovered peer unless it is already known.
+func (c *Car) addPeer(info *api.CarInfo) {
+	c.peerMutex.Lock()
+	defer c.peerMutex.Unlock()
+
+	if _, ok := c.peers[info.Identifier]; !ok {
+		c.peers[info.Identifier] = info
+	}
+}
+
+// removePeer forgets a peer and tears down its subscription and connection.
+func (c *Car) removePeer(id string) {
+	c.peerMutex.Lock()
+	delete(c.peers, id)
+	cancel, ok := c.subscriptions[id]
+	delete(c.subscriptions, id)
+	c.peerMutex.Unlock()
+
+	if ok {
+		cancel()
+	}
+	c.closePeerConn(id)
+}
+
+// updatePeers makes sure every known peer has a running SubscribeCarInfo stream.
+func (c *Car) updatePeers() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.pruneFarPeers()
+
+		c.peerMutex.Lock()
+		var missing []string
+		for id := range c.peers {
+			if _, ok := c.subscriptions[id]; !ok {
+				ctx, cancel := context.WithCancel(context.Background())
+				c.subscriptions[id] = cancel
+				missing = append(missing, id)
+				go c.subscribePeer(ctx, id)
+			}
+		}
+		c.peerMutex.Unlock()
+
+		for _, id := range missing {
+			c.logger("peers").Info("Subscribed to peer", "peer", id)
+		}
+	}
+}
+
+// pruneFarPeers drops peers which left the sensing radius so their streams are closed.
+func (c *Car) pruneFarPeers() {
+	if c.sensingRadius <= 0 {
+		return
+	}
+
+	c.peerMutex.Lock()
+	peers := make([]*api.CarInfo, 0, len(c.peers))
+	for _, peer := range c.peers {
+		peers = append(peers, peer)
+	}
+	c.peerMutex.Unlock()
+
+	for _, peer := range peers {
+		if !c.inRange(peer.Position) {
+			c.removePeer(peer.Identifier)
+		}
+	}
+}
+
+// subscribePeer receives position pushes from a peer until the stream breaks.
+func (c *Car) subscribePeer(ctx context.Context, id string) {
+	conn, err := c.peerConn(id)
+	if err != nil {
+		c.logger("peers").Warn("Failed to connect to peer", "peer", id, "err", err)
+		c.removePeer(id)
+		return
+	}
+
+	stream, err := api.NewCarClientServiceClient(conn).SubscribeCarInfo(ctx, &api.Empty{})
+	if err != nil {
+		c.logger("peers").Warn("Failed to subscribe to peer", "peer", id, "err", err)
+		c.removePeer(id)
+		return
+	}
+
+	for {
+		info, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logger("peers").Warn("Lost subscription to peer", "peer", id, "err", err)
+				c.removePeer(id)
+			}
+			return
+		}
+		c.peerMutex.Lock()
+		if ctx.Err() == nil {
+			c.peers[id] = info
+		}
+		c.peerMutex.Unlock()
+	}
+}
+
+// subscribe registers a channel which receives the latest CarInfo after every change.
+func (c *Car) subscribe() chan *api.CarInfo {
+	ch := make(chan *api.CarInfo, 1)
+	c.subMutex.Lock()
+	c.subscribers[ch] = struct{}{}
+	c.subMutex.Unlock()
+	return ch
+}
+
+func (c *Car) unsubscribe(ch chan *api.CarInfo) {
+	c.subMutex.Lock()
+	delete(c.subscribers, ch)
+	c.subMutex.Unlock()
+}
+
+// publishCarInfo pushes the current CarInfo to all subscribers, replacing any
+// update a slow subscriber has not consumed yet.
+func (c *Car) publishCarInfo() {
+	info := c.selfInfo()
+
+	c.subMutex.Lock()
+	defer c.subMutex.Unlock()
+
+	for ch := range c.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- proto.Clone(info).(*api.CarInfo)
+	}
+}
+
+// positionChanged informs subscribed peers and the coordinator about a move.
+func (c *Car) positionChanged() {
+	c.publishCarInfo()
+	c.updateCoordinator()
+}
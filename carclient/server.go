@@ -2,11 +2,14 @@ package carclient
 
 import (
 	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/security"
 	"context"
 	"fmt"
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type CarClientServiceServer struct {
@@ -15,44 +18,147 @@ type CarClientServiceServer struct {
 }
 
 func (s *CarClientServiceServer) GetCarInfo(ctx context.Context, in *api.Empty) (*api.CarInfo, error) {
-	// Implement your logic here
-	return &api.CarInfo{
-		Identifier:  s.car.CarInfo.Identifier,
-		Position:    s.car.CarInfo.Position,
-		Route:       s.car.CarInfo.Route,
-		ActiveRoute: s.car.CarInfo.ActiveRoute,
-		Color:       s.car.CarInfo.Color,
-	}, nil
+	return s.car.selfInfo(), nil
 }
 
-func (s *CarClientServiceServer) SendRoute(ctx context.Context, req *api.Route) (*api.RouteResponse, error) {
-	s.car.mu.Lock()
-	defer s.car.mu.Unlock()
+func (s *CarClientServiceServer) ReplaceItinerary(ctx context.Context, req *api.Itinerary) (*api.ItineraryAck, error) {
+	return s.car.replaceItinerary(req.Stops, req.ExpectedVersion)
+}
+
+func (s *CarClientServiceServer) AppendItinerary(ctx context.Context, req *api.Itinerary) (*api.ItineraryAck, error) {
+	return s.car.appendItinerary(req.Stops), nil
+}
+
+func (s *CarClientServiceServer) CancelItinerary(ctx context.Context, req *api.CancelRequest) (*api.ItineraryAck, error) {
+	return s.car.cancelItinerary(req.TripIds), nil
+}
+
+func (s *CarClientServiceServer) CancelRoute(ctx context.Context, req *api.CancelRequest) (*api.ItineraryAck, error) {
+	return s.car.cancelRoute(req.TripIds), nil
+}
+
+func (s *CarClientServiceServer) Recall(ctx context.Context, req *api.Empty) (*api.ItineraryAck, error) {
+	return s.car.recall()
+}
+
+func (s *CarClientServiceServer) SendCommand(ctx context.Context, req *api.Command) (*api.CommandResponse, error) {
+	switch req.Type {
+	case api.CommandType_SET_BEHAVIOR:
+		if err := s.car.commandBehavior(req.Behavior); err != nil {
+			return nil, err
+		}
+		return &api.CommandResponse{Message: "Behavior set to " + req.Behavior}, nil
+	case api.CommandType_REPOSITION:
+		if err := s.car.reposition(req.Target); err != nil {
+			return nil, err
+		}
+		return &api.CommandResponse{Message: "Repositioning"}, nil
+	case api.CommandType_PAUSE:
+		s.car.halt(false)
+		return &api.CommandResponse{Message: "Paused"}, nil
+	case api.CommandType_EMERGENCY_STOP:
+		s.car.halt(true)
+		return &api.CommandResponse{Message: "Emergency stopped"}, nil
+	case api.CommandType_RESUME:
+		s.car.resume()
+		return &api.CommandResponse{Message: "Resumed"}, nil
+	default:
+		return nil, fmt.Errorf("unknown command type %v", req.Type)
+	}
+}
+
+func (s *CarClientServiceServer) Gossip(ctx context.Context, req *api.GossipMessage) (*api.GossipMessage, error) {
+	if s.car.gossip == nil {
+		return nil, fmt.Errorf("gossip mode is not enabled")
+	}
+
+	for _, mem := range req.Members {
+		s.car.gossip.merge(mem)
+	}
+	return s.car.gossip.digest(s.car.selfInfo()), nil
+}
+
+func (s *CarClientServiceServer) PingReq(ctx context.Context, req *api.PingRequest) (*api.PingResponse, error) {
+	if s.car.gossip == nil {
+		return nil, fmt.Errorf("gossip mode is not enabled")
+	}
+
+	// Probe the target on behalf of the requesting member
+	err := s.car.gossipWith(req.Target)
+	return &api.PingResponse{Ack: err == nil}, nil
+}
+
+func (s *CarClientServiceServer) SubscribeCarInfo(req *api.Empty, stream api.CarClientService_SubscribeCarInfoServer) error {
+	ch := s.car.subscribe()
+	defer s.car.unsubscribe(ch)
 
-	// Debug output of the new route
-	fmt.Println("Received new route:")
-	for _, coord := range req.Coordinates {
-		fmt.Printf("Coordinate: X=%d, Y=%d\n", coord.X, coord.Y)
+	// Send the current state right away, then every change
+	if err := stream.Send(s.car.selfInfo()); err != nil {
+		return err
 	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case info := <-ch:
+			if err := stream.Send(info); err != nil {
+				return err
+			}
+		}
+	}
+}
 
-	s.car.CarInfo.Route = req
-	s.car.CarInfo.ActiveRoute = true
-	fmt.Println("Route updated successfully")
+// coordinatorCalls change the car's itinerary or behavior and are only
+// accepted from the coordinator. Peers may query the car and gossip freely.
+var coordinatorCalls = map[string]bool{
+	api.CarClientService_ReplaceItinerary_FullMethodName: true,
+	api.CarClientService_AppendItinerary_FullMethodName:  true,
+	api.CarClientService_CancelItinerary_FullMethodName:  true,
+	api.CarClientService_CancelRoute_FullMethodName:      true,
+	api.CarClientService_Recall_FullMethodName:           true,
+	api.CarClientService_SendCommand_FullMethodName:      true,
+}
+
+// authorize checks that routes and commands carry a token the coordinator the
+// car registered with issued for this car.
+func (car *Car) authorize(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !coordinatorCalls[info.FullMethod] {
+		return handler(ctx, req)
+	}
 
-	return &api.RouteResponse{Message: "Route received successfully"}, nil
+	car.linkMutex.Lock()
+	key := car.coordinatorKey
+	car.linkMutex.Unlock()
+	if key == nil {
+		return nil, status.Error(codes.Unauthenticated, "car is not registered yet")
+	}
+	claims, err := security.Verify(key, security.TokenFromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if claims.Role != security.RoleCoordinator {
+		return nil, status.Errorf(codes.PermissionDenied, "%s cannot send %s", claims.Subject, info.FullMethod)
+	}
+	car.mu.Lock()
+	address := car.CarInfo.Identifier
+	car.mu.Unlock()
+	if claims.Address != address {
+		return nil, status.Errorf(codes.PermissionDenied, "token for %q cannot be used at %s", claims.Address, address)
+	}
+	return handler(ctx, req)
 }
 
 func (car *Car) startCarClientServer(port string) {
-	server := grpc.NewServer()
+	server := grpc.NewServer(security.ServerOption(), grpc.UnaryInterceptor(car.authorize))
 	api.RegisterCarClientServiceServer(server, &CarClientServiceServer{car: car})
 
 	listener, err := net.Listen("tcp", port)
 	if err != nil {
-		fmt.Println("Failed to listen:", err)
+		car.logger("server").Error("Failed to listen", "err", err)
 		return
 	}
-	fmt.Println("Car client server started")
+	car.logger("server").Info("Car client server started", "address", port)
 	if err := server.Serve(listener); err != nil {
-		fmt.Println("Failed to serve:", err)
+		car.logger("server").Error("Failed to serve", "err", err)
 	}
 }
@@ -0,0 +1,66 @@
+// Package certgen creates a local certificate authority and certificates for
+// the coordinator and the cars, for running the simulation with TLS.
+package certgen
+
+import (
+	"AutonomousCarFleetSimulation/security"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func Run() {
+	out := flag.String("out", "certs", "Directory to write the certificates to")
+	cars := flag.Int("cars", 10, "Number of car certificates")
+	firstPort := flag.Int("firstPort", 50001, "Port of the first car, cars are named car-<port>")
+	validity := flag.Duration("validity", 365*24*time.Hour, "Validity of the certificates")
+	flag.Parse()
+
+	if err := generate(*out, *cars, *firstPort, *validity); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// generate writes ca.pem, coordinator.pem and car-<port>.pem with their keys
+// to out. An existing CA in out is reused, so more cars can be added later.
+func generate(out string, cars, firstPort int, validity time.Duration) error {
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+
+	caPath, caKeyPath := filepath.Join(out, "ca.pem"), filepath.Join(out, "ca-key.pem")
+	ca, caKey, err := security.ReadKeyPair(caPath, caKeyPath)
+	if os.IsNotExist(err) {
+		if ca, caKey, err = security.NewCA("Fleet Simulation Dev CA", validity); err != nil {
+			return err
+		}
+		if err := security.WriteKeyPair(ca, caKey, caPath, caKeyPath); err != nil {
+			return err
+		}
+		fmt.Println("Created CA", caPath)
+	} else if err != nil {
+		return err
+	} else {
+		fmt.Println("Using existing CA", caPath)
+	}
+
+	names := []string{"coordinator"}
+	for port := firstPort; port < firstPort+cars; port++ {
+		names = append(names, fmt.Sprintf("car-%d", port))
+	}
+	for _, name := range names {
+		cert, key, err := security.IssueCert(ca, caKey, name, security.DevHosts, validity)
+		if err != nil {
+			return err
+		}
+		certPath, keyPath := filepath.Join(out, name+".pem"), filepath.Join(out, name+"-key.pem")
+		if err := security.WriteKeyPair(cert, key, certPath, keyPath); err != nil {
+			return err
+		}
+		fmt.Println("Created", certPath)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package main
+
+import (
+	certgen "AutonomousCarFleetSimulation/certgen"
+)
+
+func main() {
+	certgen.Run()
+}
@@ -2,152 +2,444 @@ package coordinator
 
 import (
 	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
 	"AutonomousCarFleetSimulation/utils"
 	"context"
+	"flag"
+	"fmt"
 	"math"
 	"math/rand"
+	"os"
 	"sync"
 	"time"
 
-	"log"
-
 	"gioui.org/app"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	maxDetour   = 1.5 // pooled rides may be this much longer than direct ones
+	detourSlack = 2   // extra cells every pooled ride may take
 )
 
+// trip is a ride request from pickup to dropoff for a group of passengers.
+type trip struct {
+	id           string
+	pickup       *api.Coordinate
+	dropoff      *api.Coordinate
+	passengers   int32
+	requirements []string   // capabilities the vehicle needs, passengers if empty
+	route        *api.Route // direct path, drawn on the grid
+	car          string     // identifier of the assigned car, empty while pending
+	cancelled    bool
+	requested    time.Time
+
+	promisedPickup  time.Time // ETAs given when the trip was assigned
+	promisedDropoff time.Time
+	pickupETA       time.Time // latest ETAs, refined with every car update
+	dropoffETA      time.Time
+	pickedUp        time.Time
+	droppedOff      time.Time
+	seen            bool   // set once the car reported the trip in its itinerary
+	version         uint64 // itinerary version of the car which includes the trip
+}
+
 var (
 	carinfos     = make([]*api.CarInfo, 0)
+	carsByID     = make(map[string]*api.CarInfo) // same cars as carinfos, by identifier
+	dispatching  = make(map[string]bool)         // cars with an itinerary in flight, guarded by carinfoMutex
+	recalled     = make(map[string]bool)         // cars on their way back to the depot, guarded by carinfoMutex
 	carinfoMutex sync.Mutex
 	carInfoCh    = make(chan *api.CarInfo)
-	routeCh      = make(chan *api.Route)
+	tripCh       = make(chan *trip)
 	gridData     = utils.CreateDataGrid()
+	carIndex     = utils.NewSpatialIndex(4)
 )
 
-func generateRandomRoute() {
+func generateRandomTrip() {
 	for {
 		time.Sleep(10 * time.Second)
-		start := &api.Coordinate{X: int32(rand.Intn(int(utils.Settings.GridSize))), Y: int32(rand.Intn(int(utils.Settings.GridSize)))}
+		if !leading() || !fleetRunning() {
+			continue
+		}
+		area := shards.area() // Trips start in the own region
+		start := &api.Coordinate{X: area.Min.X + rand.Int31n(area.Max.X-area.Min.X+1), Y: area.Min.Y + rand.Int31n(area.Max.Y-area.Min.Y+1)}
 		end := &api.Coordinate{X: int32(rand.Intn(int(utils.Settings.GridSize))), Y: int32(rand.Intn(int(utils.Settings.GridSize)))}
-		route := utils.CalculatePath(start, end, nil)
-		routeCh <- &api.Route{Coordinates: route}
-		log.Printf("Generated Random Route from %v to %v and path %v", start, end, route)
+		t := newTrip(start, end, int32(rand.Intn(3)+1), randomRequirements())
+		tripCh <- t
+		logging.Component("trips").Info("Generated random trip", logging.TripKey, t.id, "passengers", t.passengers, "requirements", t.requirements, "pickup", start, "dropoff", end, "path", t.route.Coordinates)
+	}
+}
+
+// randomRequirements makes every tenth trip a wheelchair ride and every tenth a delivery.
+func randomRequirements() []string {
+	switch rand.Intn(10) {
+	case 0:
+		return []string{utils.Passengers, utils.Wheelchair}
+	case 1:
+		return []string{utils.Cargo}
+	default:
+		return nil
 	}
 }
 
-func sendRoute(carinfo *api.CarInfo, route *api.Route) {
+// assignment is an itinerary planned for a car, together with the itinerary
+// and version it was planned on.
+type assignment struct {
+	identifier string
+	version    uint64
+	previous   []*api.Stop
+	itinerary  []*api.Stop
+	cost       float64 // additional cells driven for the trip
+}
+
+// sendItinerary appends the new stops if the trip was queued behind the
+// previous itinerary and replaces the itinerary otherwise. A replacement is
+// rejected by the car if its itinerary changed in the meantime.
+func sendItinerary(a *assignment) (*api.ItineraryAck, error) {
 	// Set up a connection to the gRPC server.
-	conn, err := grpc.Dial(carinfo.Identifier, grpc.WithInsecure())
+	conn, err := dialCar(a.identifier)
 	if err != nil {
-		log.Fatalf("Failed to dial server: %v", err)
+		return nil, err
 	}
 	defer conn.Close()
 
 	// Create a client instance.
 	client := api.NewCarClientServiceClient(conn)
 
-	// Send the car info request to the server.
-	response, err := client.SendRoute(context.Background(), route)
+	appended := len(a.itinerary) > len(a.previous)
+	for i, stop := range a.previous {
+		if a.itinerary[i] != stop {
+			appended = false
+			break
+		}
+	}
+
+	var ack *api.ItineraryAck
+	if appended {
+		ack, err = client.AppendItinerary(context.Background(), &api.Itinerary{Stops: a.itinerary[len(a.previous):]})
+	} else {
+		ack, err = client.ReplaceItinerary(context.Background(), &api.Itinerary{Stops: a.itinerary, ExpectedVersion: a.version})
+	}
 	if err != nil {
-		log.Fatalf("Failed to send route to car: %v", err)
+		return nil, err
 	}
-	log.Printf("Response from server: %s", response.Message)
+	logging.Component("dispatch").Debug("Itinerary acknowledged", logging.CarKey, a.identifier, "response", ack.Message, "version", ack.Version, logging.TripKey, ack.CurrentLeg.GetTripId(), "stop", ack.CurrentLeg.GetType())
+	return ack, nil
+}
+
+// sendCommand forwards a command, e.g. a behavior switch, to a car at runtime.
+func sendCommand(identifier string, command *api.Command) (*api.CommandResponse, error) {
+	conn, err := dialCar(identifier)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := api.NewCarClientServiceClient(conn)
+	response, err := client.SendCommand(context.Background(), command)
+	if err != nil {
+		return nil, err
+	}
+	logging.Component("commands").Info("Command acknowledged", logging.CarKey, identifier, "response", response.Message)
+	return response, nil
 }
 
 func waitForUpdates(window *app.Window) bool {
 	for {
 		select {
 		case carInfo := <-carInfoCh:
-			var oldCarInfo = updateCarinfo(carInfo)
-			updateGridData(oldCarInfo, carInfo)
-			updateCarinfo(carInfo)
+			if shards.handedOver(carInfo.Identifier) {
+				continue // Sent before the car left the region
+			}
+			handleCarUpdate(carInfo)
 			window.Invalidate()
-		case route := <-routeCh:
-			updateGridDataRoute(route, "")
-			go sendRouteWhenFree(carinfos, route)
+		case t := <-tripCh:
+			registerTrip(t)
+			events.record(tripEvent(eventTripGenerated, t))
+			demand.record(t.pickup)
+			carinfoMutex.Lock()
+			updateGridDataRoute(t.route, "")
+			carinfoMutex.Unlock()
+			go dispatchTrip(t)
 			window.Invalidate()
 		}
 	}
 }
 
-func sendRouteWhenFree(carInfos []*api.CarInfo, route *api.Route) {
-	freeCar := findCarWithShortestPath(carInfos, route)
-	sendRoute(freeCar, route)
+// handleCarUpdate applies a CarInfo reported by a car to the fleet view and
+// the trips it serves.
+func handleCarUpdate(carInfo *api.CarInfo) {
+	var oldCarInfo = updateCarinfo(carInfo)
+	if oldCarInfo == nil {
+		events.record(carEvent(eventCarRegistered, carInfo))
+		go syncFleetState(carInfo.Identifier)
+	} else {
+		events.record(carEvent(eventPositionUpdate, carInfo))
+	}
+	if reconcileCar(oldCarInfo, carInfo) {
+		go syncFleetState(carInfo.Identifier)
+	}
+	if oldCarInfo != nil && oldCarInfo.State != carInfo.State {
+		logging.Component("fleet").Info("Car state changed", logging.CarKey, carInfo.Identifier, "state", carInfo.State, "depot", carInfo.Depot)
+	}
+	carSeen(oldCarInfo, carInfo)
+	carIndex.Update(carInfo.Identifier, carInfo.Position)
+	updateETAs(carInfo)
+	for _, id := range carInfo.AbortedTrips {
+		logging.Component("trips").Info("Trip aborted", logging.CarKey, carInfo.Identifier, logging.TripKey, id)
+		events.record(event{Type: eventTripAborted, Trip: id, Car: carInfo.Identifier})
+		tripAborted(id, carInfo.Identifier)
+	}
+	returnedFromRecall(carInfo)
+	updateGridData(oldCarInfo, carInfo)
+	updateCarinfo(carInfo)
 }
 
-func findCarWithShortestPath(carInfos []*api.CarInfo, route *api.Route) *api.CarInfo {
-	if len(route.Coordinates) == 0 {
-		return nil
+// dispatchTrip assigns the trip to the car which needs the fewest additional
+// cells for it, pooling it with the trips the car already serves if possible.
+func dispatchTrip(t *trip) {
+	for {
+		a := findCarForTrip(t)
+		if a == nil {
+			return // Cancelled before any car took it or no longer leading
+		}
+		ack, err := sendItinerary(a)
+		if err != nil {
+			release(a.identifier)
+			if status.Code(err) == codes.Aborted {
+				// The car changed its itinerary since its last report, plan again on the next one
+				logging.Component("dispatch").Info("Itinerary changed on the car, planning again", logging.CarKey, a.identifier, logging.TripKey, t.id, "err", err)
+			} else {
+				logging.Component("dispatch").Warn("Failed to send itinerary", logging.CarKey, a.identifier, logging.TripKey, t.id, "err", err)
+			}
+			time.Sleep(1 * time.Second)
+			continue
+		}
+
+		var etas map[stopKey]time.Time
+		car := acknowledge(a.identifier, ack) // nil if the car left the fleet view meanwhile
+		if car != nil {
+			etas = stopETAs(car)
+		}
+		if assignTrip(t, a.identifier, etas, ack.Version) {
+			// Cancelled while the itinerary was in flight, the cancellation could not reach the car yet
+			if err := cancelOnCar(a.identifier, t.id); err != nil {
+				logging.Component("trips").Warn("Failed to cancel trip on the car", logging.TripKey, t.id, logging.CarKey, a.identifier, "err", err)
+			}
+			clearTripRoute(t.id)
+			return
+		}
+		carinfoMutex.Lock()
+		updateGridDataRoute(t.route, car.GetColor())
+		carinfoMutex.Unlock()
+		shards.shareRoute(t.id, t.route, car.GetColor(), false)
+		events.record(event{Type: eventTripAssigned, Trip: t.id, Car: a.identifier})
+		logging.Component("dispatch").Info("Assigned trip", logging.TripKey, t.id, logging.CarKey, a.identifier, "stops", len(ack.Stops), "extra_cells", a.cost, "pickup_eta", time.Until(etas[stopKey{t.id, api.StopType_PICKUP}]).Round(time.Second))
+		dispatchLatency.Observe(time.Since(t.requested).Seconds())
+		return
+	}
+}
+
+// release lets the next assignment of the car be planned.
+func release(identifier string) {
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+	delete(dispatching, identifier)
+}
+
+// acknowledge applies the itinerary a car acknowledged to the fleet view,
+// unless the car reported a newer one meanwhile, and returns the car. The
+// next assignment of the car is planned on this itinerary.
+func acknowledge(identifier string, ack *api.ItineraryAck) *api.CarInfo {
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+	delete(dispatching, identifier)
+
+	car, ok := carsByID[identifier]
+	if !ok || ack.Version < car.ItineraryVersion {
+		return car
 	}
-	startPoint := route.Coordinates[0]
+	car = proto.Clone(car).(*api.CarInfo) // The reported CarInfo may still be read elsewhere
+	car.Itinerary = ack.Stops
+	car.ItineraryVersion = ack.Version
+	car.CurrentLeg = ack.CurrentLeg
+	car.ActiveRoute = len(ack.Stops) > 0
+	storeCarinfo(car)
+	return car
+}
+
+// servingCar returns the car whose itinerary already holds the trip, e.g.
+// because the car accepted it but the acknowledgement got lost. Must be
+// called with carinfoMutex held.
+func servingCar(id string) *api.CarInfo {
+	for _, carInfo := range carinfos {
+		for _, stop := range carInfo.Itinerary {
+			if stop.TripId == id {
+				return carInfo
+			}
+		}
+	}
+	return nil
+}
+
+func findCarForTrip(t *trip) *assignment {
+	pickup := &api.Stop{TripId: t.id, Type: api.StopType_PICKUP, Position: t.pickup, Passengers: t.passengers}
+	dropoff := &api.Stop{TripId: t.id, Type: api.StopType_DROPOFF, Position: t.dropoff, Passengers: t.passengers}
 
 	for {
+		if isCancelled(t) || !leading() {
+			return nil // The new leader dispatches the trip
+		}
+		if !fleetRunning() {
+			time.Sleep(1 * time.Second)
+			continue
+		}
+
+		online := onlineCars()
 		carinfoMutex.Lock()
-		var shortestCar *api.CarInfo
-		shortestLength := math.MaxFloat64
-
-		for _, carInfo := range carInfos {
-			if !carInfo.ActiveRoute {
-				dist := utils.Distance(carInfo.Position, startPoint)
-				if dist < shortestLength {
-					shortestLength = dist
-					shortestCar = carInfo
+		if car := servingCar(t.id); car != nil {
+			etas := stopETAs(car)
+			carinfoMutex.Unlock()
+			logging.Component("dispatch").Info("Trip is already on an itinerary", logging.TripKey, t.id, logging.CarKey, car.Identifier)
+			if assignTrip(t, car.Identifier, etas, car.ItineraryVersion) {
+				if err := cancelOnCar(car.Identifier, t.id); err != nil {
+					logging.Component("trips").Warn("Failed to cancel trip on the car", logging.TripKey, t.id, logging.CarKey, car.Identifier, "err", err)
 				}
 			}
+			return nil
 		}
 
-		if shortestCar != nil {
-			shortestCar.ActiveRoute = true
-			shortestCar.Route = route
-			updateGridDataRoute(route, shortestCar.Color)
+		var bestCar *api.CarInfo
+		var bestItinerary []*api.Stop
+		bestCost := math.MaxFloat64
+
+		for _, carInfo := range carinfos {
+			if recalled[carInfo.Identifier] || !online[carInfo.Identifier] {
+				continue // Out of service until it is back at its depot or reports again
+			}
+			vehicle, _ := utils.LookupVehicleType(carInfo.VehicleType)
+			if !vehicle.Serves(t.requirements) || !vehicle.Allows(t.pickup) || !vehicle.Allows(t.dropoff) {
+				continue
+			}
+			limits := utils.PoolingLimits{Seats: carInfo.SeatCapacity, MaxDetour: maxDetour, DetourSlack: detourSlack}
+			itinerary, cost, ok := utils.InsertTrip(carInfo.Position, carInfo.Passengers, carInfo.Itinerary, pickup, dropoff, limits)
+			if !ok || !canComplete(carInfo, itinerary) {
+				continue
+			}
+			if cost < bestCost {
+				bestCost = cost
+				bestCar = carInfo
+				bestItinerary = itinerary
+			}
+		}
+
+		if bestCar != nil && dispatching[bestCar.Identifier] {
+			// Plan on the itinerary the car acknowledges for the assignment in flight
 			carinfoMutex.Unlock()
-			log.Printf("Shortest Path to route: %v", shortestCar.Identifier)
-			return shortestCar
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		if bestCar != nil {
+			// The fleet view only changes once the car acknowledged the itinerary
+			dispatching[bestCar.Identifier] = true
+			a := &assignment{
+				identifier: bestCar.Identifier,
+				version:    bestCar.ItineraryVersion,
+				previous:   bestCar.Itinerary,
+				itinerary:  bestItinerary,
+				cost:       bestCost,
+			}
+			carinfoMutex.Unlock()
+			return a
 		}
 		carinfoMutex.Unlock()
-		log.Println("No free car found, waiting for 1 second")
+		logging.Component("dispatch").Debug("No vehicle with free seats, enough energy and capabilities, waiting for 1 second", logging.TripKey, t.id, "requirements", t.requirements)
 		time.Sleep(1 * time.Second)
 	}
 }
 
+// canComplete reports whether the car has enough energy to drive through the
+// itinerary and on to a charging station afterwards.
+func canComplete(carInfo *api.CarInfo, itinerary []*api.Stop) bool {
+	if carInfo.EnergyCapacity == 0 {
+		return true // Car without energy model
+	}
+
+	cells := utils.ItineraryLength(carInfo.Position, itinerary)
+	end := itinerary[len(itinerary)-1].Position
+	if station := utils.NearestChargingStation(end); station != nil {
+		cells += utils.Distance(end, station)
+	}
+	return carInfo.Energy >= cells*carInfo.ConsumptionPerCell
+}
+
 func updateCarinfo(newCarInfo *api.CarInfo) *api.CarInfo {
 	carinfoMutex.Lock()
 	defer carinfoMutex.Unlock()
 
-	var oldCarInfo *api.CarInfo
-	for i, car := range carinfos {
-		if car.Identifier == newCarInfo.Identifier {
-			// Save the old carinfo
-			oldCarInfo = car
+	return storeCarinfo(newCarInfo)
+}
 
-			// Update the carinfo slice with the new carinfo
-			carinfos[i] = newCarInfo
+// storeCarinfo puts the CarInfo into the fleet view and returns the one it
+// replaced. Must be called with carinfoMutex held.
+func storeCarinfo(newCarInfo *api.CarInfo) *api.CarInfo {
+	oldCarInfo, ok := carsByID[newCarInfo.Identifier]
+	carsByID[newCarInfo.Identifier] = newCarInfo
+	if !ok {
+		// Append new CarInfo if not found
+		carinfos = append(carinfos, newCarInfo)
+		return nil
+	}
 
-			return oldCarInfo
+	// Update the carinfo slice with the new carinfo
+	for i, car := range carinfos {
+		if car == oldCarInfo {
+			carinfos[i] = newCarInfo
+			break
 		}
 	}
+	return oldCarInfo
+}
 
-	// Append new CarInfo if not found
-	carinfos = append(carinfos, newCarInfo)
-	return nil
+// nearbyCars returns the latest CarInfo of every car within radius of position.
+func nearbyCars(identifier string, position *api.Coordinate, radius int) []*api.CarInfo {
+	ids := carIndex.Query(position, radius)
+
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+
+	var result []*api.CarInfo
+	for _, id := range ids {
+		if car, ok := carsByID[id]; ok && id != identifier {
+			result = append(result, car)
+		}
+	}
+	return result
 }
 
 func updateGridData(oldCarInfo *api.CarInfo, newCarInfo *api.CarInfo) {
 	carinfoMutex.Lock()
 	defer carinfoMutex.Unlock()
 
-	if oldCarInfo != nil && gridData[oldCarInfo.Position.X][oldCarInfo.Position.Y][0] == utils.Settings.CarAscii {
+	vehicle, _ := utils.LookupVehicleType(newCarInfo.VehicleType)
+
+	if oldCarInfo != nil && utils.IsVehicle(gridData[oldCarInfo.Position.X][oldCarInfo.Position.Y][0]) {
 		// Delete old position of car
-		gridData[oldCarInfo.Position.X][oldCarInfo.Position.Y] = [2]string{utils.Settings.EmptyAscii, ""}
+		gridData[oldCarInfo.Position.X][oldCarInfo.Position.Y] = utils.EmptyCell(oldCarInfo.Position.X, oldCarInfo.Position.Y)
 	}
 
 	// If new field empty: Set CarAscii
-	if gridData[newCarInfo.Position.X][newCarInfo.Position.Y][0] == utils.Settings.EmptyAscii {
-		gridData[newCarInfo.Position.X][newCarInfo.Position.Y] = [2]string{utils.Settings.CarAscii, newCarInfo.Color}
+	if utils.IsFree(gridData[newCarInfo.Position.X][newCarInfo.Position.Y]) {
+		gridData[newCarInfo.Position.X][newCarInfo.Position.Y] = [2]string{vehicle.Ascii, newCarInfo.Color}
 	}
 	// If new field route
 	if gridData[newCarInfo.Position.X][newCarInfo.Position.Y][0] == utils.Settings.RouteAscii {
 		var isRoute bool = false
-		for _, coord := range newCarInfo.Route.Coordinates {
+		for _, coord := range newCarInfo.GetRoute().GetCoordinates() {
 			if coord.X == newCarInfo.Position.X && coord.Y == newCarInfo.Position.Y {
 				isRoute = true
 				break
@@ -155,9 +447,9 @@ func updateGridData(oldCarInfo *api.CarInfo, newCarInfo *api.CarInfo) {
 		}
 		// if field is coord of own route: Set CarAscii
 		if isRoute {
-			gridData[newCarInfo.Position.X][newCarInfo.Position.Y] = [2]string{utils.Settings.CarAscii, newCarInfo.Color}
+			gridData[newCarInfo.Position.X][newCarInfo.Position.Y] = [2]string{vehicle.Ascii, newCarInfo.Color}
 			if oldCarInfo != nil {
-				gridData[oldCarInfo.Position.X][oldCarInfo.Position.Y] = [2]string{utils.Settings.EmptyAscii, ""}
+				gridData[oldCarInfo.Position.X][oldCarInfo.Position.Y] = utils.EmptyCell(oldCarInfo.Position.X, oldCarInfo.Position.Y)
 			}
 		} else {
 			// if field is not coord in own route: Set CarAndRouteAscii with color of old value
@@ -170,6 +462,8 @@ func updateGridData(oldCarInfo *api.CarInfo, newCarInfo *api.CarInfo) {
 	}
 }
 
+// updateGridDataRoute draws a route on the grid. Must be called with
+// carinfoMutex held.
 func updateGridDataRoute(route *api.Route, color string) {
 	for _, coord := range route.Coordinates {
 		gridData[coord.X][coord.Y] = [2]string{utils.Settings.RouteAscii, color}
@@ -177,14 +471,110 @@ func updateGridDataRoute(route *api.Route, color string) {
 }
 
 func Run() {
+	metricsAddress := flag.String("metrics", ":2112", "Address to serve Prometheus metrics on (empty = disabled)")
+	logLevel := flag.String("logLevel", "info", "Log level, one of debug, info, warn or error")
+	logJSON := flag.Bool("logJSON", false, "Write logs as JSON lines")
+	eventLogPath := flag.String("eventLog", "", "Append all simulation events to this JSONL file (empty = disabled)")
+	tlsCA := flag.String("tlsCA", "", "CA certificate for mutual TLS (empty = insecure connections)")
+	tlsCert := flag.String("tlsCert", "", "Own certificate for mutual TLS")
+	tlsKey := flag.String("tlsKey", "", "Key of the own certificate")
+	signingKey := flag.String("signingKey", "", "Key file tokens of cars and coordinators are signed with, created if missing; required with -replicas or -shards (empty = new key per start)")
+	operatorToken := flag.String("operatorToken", "", "Write a token for commands, recalls, cancellations, fleet state changes and ride requests to this file, valid for a day (empty = only cars may request rides and command themselves)")
+	replayPath := flag.String("replay", "", "Play back a recorded event log instead of running the simulation")
+	snapshotPath := flag.String("snapshot", "", "Restore the coordinator state from this file on startup and write snapshots to it (empty = disabled)")
+	snapshotInterval := flag.Duration("snapshotInterval", 10*time.Second, "Interval between two snapshots")
+	port := flag.Int("port", 50000, "Port for cars and replicas to connect to")
+	replicas := flag.String("replicas", "", "Comma separated addresses of all coordinator replicas including this one, e.g. localhost:50000,localhost:50200,localhost:50300 (empty = single coordinator)")
+	replicationInterval := flag.Duration("replicationInterval", 1*time.Second, "Interval in which the leader replicates its state")
+	raftState := flag.String("raftState", "", "File the replica keeps its Raft term and vote in, so it cannot vote twice in a term after a restart (empty = raft-<port>.state)")
+	shardList := flag.String("shards", "", "Comma separated coordinator addresses, one per region; the grid is split into that many vertical strips (empty = not sharded)")
+	region := flag.Int("region", 0, "Index of the region owned by this coordinator when sharded")
+	flag.Parse()
+
+	if err := logging.Setup(*logLevel, *logJSON); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	if err := security.SetupTLS(*tlsCA, *tlsCert, *tlsKey); err != nil {
+		logging.Component("tls").Error("Failed to set up TLS", "err", err)
+		os.Exit(2)
+	}
+	if *signingKey == "" && (*replicas != "" || *shardList != "") {
+		logging.Component("identity").Error("Replicas and shards need a shared -signingKey")
+		os.Exit(2)
+	}
+	if err := setupIdentity(*signingKey); err != nil {
+		logging.Component("identity").Error("Failed to set up signing key", "path", *signingKey, "err", err)
+		os.Exit(2)
+	}
+	if *operatorToken != "" {
+		if err := writeOperatorToken(*operatorToken); err != nil {
+			logging.Component("identity").Error("Failed to write operator token", "path", *operatorToken, "err", err)
+			os.Exit(2)
+		}
+	}
+	if *replayPath != "" {
+		runReplay(*replayPath)
+		return
+	}
+	if *eventLogPath != "" {
+		var err error
+		if events, err = openEventLog(*eventLogPath); err != nil {
+			logging.Component("events").Error("Failed to open event log", "path", *eventLogPath, "err", err)
+			os.Exit(1)
+		}
+	}
 
-	go startServer()
+	if *metricsAddress != "" {
+		go startMetricsServer(*metricsAddress)
+	}
 
-	go generateRandomRoute()
+	if *snapshotPath != "" {
+		if err := restoreSnapshot(*snapshotPath, *replicas == ""); err != nil {
+			logging.Component("snapshot").Error("Failed to restore snapshot", "path", *snapshotPath, "err", err)
+			os.Exit(1)
+		}
+		go snapshotPeriodically(*snapshotPath, *snapshotInterval)
+	}
 
 	window := new(app.Window)
 
-	go display(window)
+	if *shardList != "" {
+		if *replicas != "" {
+			logging.Component("shards").Error("Sharding and replication cannot be combined")
+			os.Exit(2)
+		}
+		var err error
+		if shards, err = newSharding(*region, splitAddresses(*shardList)); err != nil {
+			logging.Component("shards").Error("Invalid sharding", "err", err)
+			os.Exit(2)
+		}
+		tripPrefix = fmt.Sprintf("trip-r%d", *region)
+		area := shards.area()
+		window.Option(app.Title(fmt.Sprintf("Coordinator region %d (x %d-%d)", *region, area.Min.X, area.Max.X)))
+		logging.Component("shards").Info("Owning region", "region", *region, "area", area, "regions", len(shards.owners))
+	}
+
+	if *replicas != "" {
+		self := fmt.Sprintf("localhost:%d", *port)
+		if *raftState == "" {
+			*raftState = fmt.Sprintf("raft-%d.state", *port)
+		}
+		if err := startReplication(self, splitAddresses(*replicas), *raftState, *replicationInterval, window); err != nil {
+			logging.Component("ha").Error("Failed to start replication", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	go startServer(fmt.Sprintf(":%d", *port))
+
+	go generateRandomTrip()
+
+	go rebalanceIdleCars()
+
+	go expireOfflineCars()
+
+	go display(window, handleShortcuts)
 
 	go waitForUpdates(window)
 
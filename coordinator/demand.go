@@ -0,0 +1,172 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/utils"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	demandWindow      = 5 * time.Minute  // how long a route origin counts as demand
+	demandZoneSize    = 4                // edge length of the heatmap zones in cells
+	rebalanceInterval = 15 * time.Second // how often idle cars are repositioned
+	coveredDistance   = 2                // hotspots with a car this close need no rebalancing
+	rebalanceBehavior = "rebalance"      // behavior reported by cars while repositioning
+)
+
+type demandSample struct {
+	origin *api.Coordinate
+	at     time.Time
+}
+
+// demandHeatmap counts recent route origins per zone of the grid.
+type demandHeatmap struct {
+	mu      sync.Mutex
+	samples []demandSample
+}
+
+var demand = &demandHeatmap{}
+
+func (d *demandHeatmap) record(origin *api.Coordinate) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.samples = append(d.samples, demandSample{origin: origin, at: time.Now()})
+}
+
+// hotspots returns the centers of the n busiest zones, busiest first.
+func (d *demandHeatmap) hotspots(n int) []*api.Coordinate {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Forget samples which left the window
+	cutoff := time.Now().Add(-demandWindow)
+	recent := d.samples[:0]
+	for _, sample := range d.samples {
+		if sample.at.After(cutoff) {
+			recent = append(recent, sample)
+		}
+	}
+	d.samples = recent
+
+	counts := make(map[[2]int32]int)
+	for _, sample := range d.samples {
+		zone := [2]int32{sample.origin.X / demandZoneSize, sample.origin.Y / demandZoneSize}
+		counts[zone]++
+	}
+
+	zones := make([][2]int32, 0, len(counts))
+	for zone := range counts {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool { return counts[zones[i]] > counts[zones[j]] })
+	if len(zones) > n {
+		zones = zones[:n]
+	}
+
+	centers := make([]*api.Coordinate, 0, len(zones))
+	for _, zone := range zones {
+		center := &api.Coordinate{
+			X: int32(math.Min(float64(zone[0]*demandZoneSize+demandZoneSize/2), float64(utils.Settings.GridSize-1))),
+			Y: int32(math.Min(float64(zone[1]*demandZoneSize+demandZoneSize/2), float64(utils.Settings.GridSize-1))),
+		}
+		centers = append(centers, center)
+	}
+	return centers
+}
+
+// rebalanceIdleCars periodically sends idle cars towards the busiest zones
+// which have no car nearby, so that future pickups are closer.
+func rebalanceIdleCars() {
+	for {
+		time.Sleep(rebalanceInterval)
+		if !leading() || !fleetRunning() {
+			continue
+		}
+		rebalance()
+	}
+}
+
+// rebalance sends the closest idle car to each uncovered hotspot and returns
+// the targets by car. Recalled and offline cars are left alone. A car is sent to the cell nearest to the hotspot its
+// vehicle type can reach.
+func rebalance() map[string]*api.Coordinate {
+	online := onlineCars()
+	carinfoMutex.Lock()
+	var idle []*api.CarInfo
+	var positions []*api.Coordinate
+	for _, car := range carinfos {
+		if !online[car.Identifier] {
+			continue // Neither covers a hotspot nor takes commands
+		}
+		positions = append(positions, car.Position)
+		if !car.ActiveRoute && car.Behavior != rebalanceBehavior && !recalled[car.Identifier] {
+			idle = append(idle, car)
+		}
+	}
+	carinfoMutex.Unlock()
+
+	sent := make(map[string]*api.Coordinate)
+	if len(idle) == 0 {
+		return sent
+	}
+
+	for _, hotspot := range demand.hotspots(len(idle)) {
+		if covered(hotspot, positions) {
+			continue
+		}
+
+		best, bestLength := -1, 0
+		var bestTarget *api.Coordinate
+		for i, car := range idle {
+			target, length := reachableTarget(car, hotspot)
+			if target != nil && (best == -1 || length < bestLength) {
+				best, bestLength, bestTarget = i, length, target
+			}
+		}
+		if best == -1 {
+			continue
+		}
+
+		car := idle[best]
+		idle = append(idle[:best], idle[best+1:]...)
+		sent[car.Identifier] = bestTarget
+		logging.Component("rebalance").Info("Rebalancing towards demand", logging.CarKey, car.Identifier, "target", bestTarget)
+		go func(identifier string, target *api.Coordinate) {
+			if _, err := sendCommand(identifier, &api.Command{Type: api.CommandType_REPOSITION, Target: target}); err != nil {
+				logging.Component("rebalance").Warn("Failed to rebalance", logging.CarKey, identifier, "err", err)
+			}
+		}(car.Identifier, bestTarget)
+	}
+	return sent
+}
+
+// reachableTarget returns the cell nearest to hotspot the car may drive on
+// and the length of its path there, or nil if the car cannot reach it.
+func reachableTarget(car *api.CarInfo, hotspot *api.Coordinate) (*api.Coordinate, int) {
+	vehicle, _ := utils.LookupVehicleType(car.VehicleType)
+	target := vehicle.NearestAllowed(hotspot)
+	if target == nil {
+		return nil, 0
+	}
+	if target.X == car.Position.X && target.Y == car.Position.Y {
+		return target, 0
+	}
+	path := utils.CalculatePathFor(car.Position, target, vehicle)
+	if len(path) == 0 {
+		return nil, 0
+	}
+	return target, len(path) - 1
+}
+
+func covered(target *api.Coordinate, positions []*api.Coordinate) bool {
+	for _, pos := range positions {
+		if utils.Distance(pos, target) <= coveredDistance {
+			return true
+		}
+	}
+	return false
+}
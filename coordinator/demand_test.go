@@ -0,0 +1,122 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/utils"
+	"testing"
+	"time"
+)
+
+// setupDemand starts with an empty heatmap.
+func setupDemand(t *testing.T) {
+	demand = &demandHeatmap{}
+	t.Cleanup(func() { demand = &demandHeatmap{} })
+}
+
+// recordDemand records count route origins at origin.
+func recordDemand(count int, origin *api.Coordinate) {
+	for i := 0; i < count; i++ {
+		demand.record(origin)
+	}
+}
+
+func TestRebalanceTargetsCellsTheVehicleCanReach(t *testing.T) {
+	car := setupFleet(t)
+	// Zone (2,2) has its center at (10,10) in the pedestrian zone
+	setupDemand(t)
+	recordDemand(3, &api.Coordinate{X: 9, Y: 9})
+
+	target := rebalance()[car.address]
+	if target == nil {
+		t.Fatal("expected the idle car to be sent towards the demand")
+	}
+	car.waitForCommand(t, api.CommandType_REPOSITION)
+	if utils.RoadClassAt(target) == utils.Pedestrian {
+		t.Errorf("car was sent into the pedestrian zone at %v", target)
+	}
+	vehicle, _ := utils.LookupVehicleType(utils.DefaultVehicleType)
+	if path := utils.CalculatePathFor(&api.Coordinate{X: 1, Y: 1}, target, vehicle); len(path) == 0 {
+		t.Errorf("car cannot reach its target %v", target)
+	}
+	if d := utils.Distance(target, &api.Coordinate{X: 10, Y: 10}); d != 2 {
+		t.Errorf("expected the target next to the pedestrian zone, got %v at distance %v", target, d)
+	}
+}
+
+func TestHotspotsAreBusiestZonesWithinTheWindow(t *testing.T) {
+	setupDemand(t)
+	recordDemand(3, &api.Coordinate{X: 1, Y: 1})
+	recordDemand(1, &api.Coordinate{X: 0, Y: 0})
+	recordDemand(2, &api.Coordinate{X: 13, Y: 14})
+	recordDemand(1, &api.Coordinate{X: 5, Y: 5})
+	// Origins older than the window no longer count
+	for i := 0; i < 5; i++ {
+		demand.samples = append(demand.samples, demandSample{origin: &api.Coordinate{X: 6, Y: 6}, at: time.Now().Add(-2 * demandWindow)})
+	}
+
+	for _, c := range []struct {
+		n    int
+		want []*api.Coordinate
+	}{
+		{1, []*api.Coordinate{{X: 2, Y: 2}}},
+		{3, []*api.Coordinate{{X: 2, Y: 2}, {X: 6, Y: 6}, {X: 14, Y: 14}}},
+	} {
+		got := demand.hotspots(c.n)
+		if len(got) != len(c.want) {
+			t.Fatalf("hotspots(%d): got %v, want %v", c.n, got, c.want)
+		}
+		if got[0].X != c.want[0].X || got[0].Y != c.want[0].Y {
+			t.Errorf("hotspots(%d): busiest is %v, want %v", c.n, got[0], c.want[0])
+		}
+		for _, want := range c.want[1:] {
+			found := false
+			for _, center := range got {
+				found = found || (center.X == want.X && center.Y == want.Y)
+			}
+			if !found {
+				t.Errorf("hotspots(%d): %v misses %v", c.n, got, want)
+			}
+		}
+	}
+	if len(demand.samples) != 7 {
+		t.Errorf("expected the expired samples to be forgotten, %d left", len(demand.samples))
+	}
+}
+
+func TestRebalanceSkipsRecalledOfflineAndCoveringCars(t *testing.T) {
+	car := setupFleet(t)
+	recalledCar := startFakeCar(t)
+	addFakeCar(recalledCar, &api.Coordinate{X: 13, Y: 13})
+	offline := startFakeCar(t)
+	addFakeCar(offline, &api.Coordinate{X: 6, Y: 6})
+	silence(offline)
+	carinfoMutex.Lock()
+	recalled[recalledCar.address] = true
+	carinfoMutex.Unlock()
+
+	// The offline car does not cover the busiest zone around (6,6)
+	setupDemand(t)
+	recordDemand(3, &api.Coordinate{X: 6, Y: 6})
+	recordDemand(2, &api.Coordinate{X: 13, Y: 13})
+	sent := rebalance()
+	if _, ok := sent[recalledCar.address]; ok {
+		t.Error("recalled car was rebalanced")
+	}
+	if _, ok := sent[offline.address]; ok {
+		t.Error("offline car was rebalanced")
+	}
+	target := sent[car.address]
+	if len(sent) != 1 || target == nil || target.X != 6 || target.Y != 6 {
+		t.Errorf("expected only the online car to be sent to (6,6), got %v", sent)
+	}
+	if target != nil {
+		car.waitForCommand(t, api.CommandType_REPOSITION)
+	}
+
+	// A hotspot with an online car nearby needs nobody
+	setupDemand(t)
+	recordDemand(2, &api.Coordinate{X: 1, Y: 1})
+	if sent := rebalance(); len(sent) != 0 {
+		t.Errorf("expected a covered hotspot to be left alone, got %v", sent)
+	}
+}
@@ -0,0 +1,467 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/utils"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCar serves the itinerary calls of a car and fails the first calls on request.
+type fakeCar struct {
+	api.CarClientServiceServer
+	address   string
+	mu        sync.Mutex
+	failures  int // calls still to fail
+	stops     []*api.Stop
+	version   uint64
+	conflicts int // replacements rejected for a stale version
+	cancelled []string
+	recalls   int
+	commands  []api.CommandType
+	onAssign  func() // called once when the next itinerary arrives
+	onRecall  func() // called when a recall arrives
+}
+
+func startFakeCar(t *testing.T) *fakeCar {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	car := &fakeCar{address: listener.Addr().String()}
+	server := grpc.NewServer()
+	api.RegisterCarClientServiceServer(server, car)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return car
+}
+
+func (f *fakeCar) assigning() {
+	f.mu.Lock()
+	hook := f.onAssign
+	f.onAssign = nil
+	f.mu.Unlock()
+	if hook != nil {
+		hook()
+	}
+}
+
+func (f *fakeCar) fail() error {
+	if f.failures > 0 {
+		f.failures--
+		return status.Error(codes.Unavailable, "car is busy")
+	}
+	return nil
+}
+
+func (f *fakeCar) ack() *api.ItineraryAck {
+	return &api.ItineraryAck{Version: f.version, Stops: f.stops}
+}
+
+func (f *fakeCar) ReplaceItinerary(ctx context.Context, req *api.Itinerary) (*api.ItineraryAck, error) {
+	f.assigning()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != f.version {
+		f.conflicts++
+		return nil, status.Errorf(codes.Aborted, "itinerary version is %d, expected %d", f.version, req.ExpectedVersion)
+	}
+	f.stops = req.Stops
+	f.version++
+	return f.ack(), nil
+}
+
+func (f *fakeCar) AppendItinerary(ctx context.Context, req *api.Itinerary) (*api.ItineraryAck, error) {
+	f.assigning()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	f.stops = append(f.stops, req.Stops...)
+	f.version++
+	return f.ack(), nil
+}
+
+func (f *fakeCar) CancelRoute(ctx context.Context, req *api.CancelRequest) (*api.ItineraryAck, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled = append(f.cancelled, req.TripIds...)
+	var kept []*api.Stop
+	for _, stop := range f.stops {
+		if !contains(req.TripIds, stop.TripId) {
+			kept = append(kept, stop)
+		}
+	}
+	f.stops = kept
+	f.version++
+	return f.ack(), nil
+}
+
+func (f *fakeCar) Recall(ctx context.Context, req *api.Empty) (*api.ItineraryAck, error) {
+	if f.onRecall != nil {
+		f.onRecall()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	f.recalls++
+	f.stops = nil
+	f.version++
+	return f.ack(), nil
+}
+
+func (f *fakeCar) SendCommand(ctx context.Context, req *api.Command) (*api.CommandResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, req.Type)
+	if req.Type == api.CommandType_EMERGENCY_STOP {
+		f.stops = nil
+		f.version++
+	}
+	return &api.CommandResponse{Message: req.Type.String()}, nil
+}
+
+// waitForCommand waits until the car received a command of the given type.
+func (f *fakeCar) waitForCommand(t *testing.T, commandType api.CommandType) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		f.mu.Lock()
+		received := false
+		for _, c := range f.commands {
+			received = received || c == commandType
+		}
+		f.mu.Unlock()
+		if received {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s received no %v command", f.address, commandType)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (f *fakeCar) tripStops(id string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, stop := range f.stops {
+		if stop.TripId == id {
+			count++
+		}
+	}
+	return count
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// setupFleet starts with an empty fleet view and one fake car in it.
+func setupFleet(t *testing.T) *fakeCar {
+	resetFleetView()
+	if err := setupIdentity(""); err != nil {
+		t.Fatal(err)
+	}
+	car := startFakeCar(t)
+	addFakeCar(car, &api.Coordinate{X: 1, Y: 1})
+	return car
+}
+
+func addFakeCar(car *fakeCar, pos *api.Coordinate) {
+	updateCarinfo(&api.CarInfo{Identifier: car.address, Position: pos, VehicleType: utils.DefaultVehicleType, SeatCapacity: 4})
+	carIndex.Update(car.address, pos)
+	carSeen(nil, &api.CarInfo{Identifier: car.address, Position: pos})
+}
+
+func TestDispatchRetryDoesNotDuplicateStops(t *testing.T) {
+	car := setupFleet(t)
+	car.failures = 1
+
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	dispatchTrip(tr)
+
+	if stops := car.tripStops(tr.id); stops != 2 {
+		t.Errorf("expected pickup and dropoff once on the car, got %d stops", stops)
+	}
+	tripMutex.Lock()
+	assigned := tr.car
+	tripMutex.Unlock()
+	if assigned != car.address {
+		t.Errorf("expected the trip to be assigned to %s, got %q", car.address, assigned)
+	}
+	carinfoMutex.Lock()
+	viewed := len(carsByID[car.address].Itinerary)
+	carinfoMutex.Unlock()
+	if viewed != 2 {
+		t.Errorf("expected the acknowledged itinerary in the fleet view, got %d stops", viewed)
+	}
+}
+
+func TestConcurrentDispatchesToOneCar(t *testing.T) {
+	car := setupFleet(t)
+
+	// A trip far away makes the car replace its itinerary for the trips next to it
+	far := newTrip(&api.Coordinate{X: 14, Y: 14}, &api.Coordinate{X: 15, Y: 15}, 1, nil)
+	registerTrip(far)
+	dispatchTrip(far)
+
+	near := []*trip{
+		newTrip(&api.Coordinate{X: 1, Y: 2}, &api.Coordinate{X: 1, Y: 4}, 1, nil),
+		newTrip(&api.Coordinate{X: 2, Y: 1}, &api.Coordinate{X: 3, Y: 1}, 1, nil),
+	}
+	var wg sync.WaitGroup
+	for _, tr := range near {
+		registerTrip(tr)
+		wg.Add(1)
+		go func(tr *trip) {
+			defer wg.Done()
+			dispatchTrip(tr)
+		}(tr)
+	}
+	wg.Wait()
+
+	for _, tr := range append(near, far) {
+		if stops := car.tripStops(tr.id); stops != 2 {
+			t.Errorf("expected pickup and dropoff of %s on the car, got %d stops", tr.id, stops)
+		}
+	}
+	car.mu.Lock()
+	defer car.mu.Unlock()
+	if car.conflicts != 0 {
+		t.Errorf("expected the dispatches to wait for each other, got %d version conflicts", car.conflicts)
+	}
+}
+
+func TestCancelDuringAssignmentReachesCar(t *testing.T) {
+	car := setupFleet(t)
+
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	car.onAssign = func() {
+		if err := cancelTrip(tr.id); err != nil {
+			t.Error(err)
+		}
+	}
+	dispatchTrip(tr)
+
+	car.mu.Lock()
+	cancelled := contains(car.cancelled, tr.id)
+	car.mu.Unlock()
+	if !cancelled {
+		t.Error("expected the cancellation to be sent after the car acknowledged the trip")
+	}
+	if stops := car.tripStops(tr.id); stops != 0 {
+		t.Errorf("expected the stops of the cancelled trip to be removed, got %d", stops)
+	}
+}
+
+func TestRecalledCarGetsNoTripsAndAbortedTripsAreRequeued(t *testing.T) {
+	recalledCar := setupFleet(t)
+	other := startFakeCar(t)
+	addFakeCar(other, &api.Coordinate{X: 8, Y: 8})
+
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	dispatchTrip(tr)
+	if stops := recalledCar.tripStops(tr.id); stops != 2 {
+		t.Fatalf("expected the nearest car to get the trip, got %d stops", stops)
+	}
+
+	if err := recallCar(recalledCar.address); err != nil {
+		t.Fatal(err)
+	}
+	depot := utils.Settings.Depots[1]
+	handleCarUpdate(&api.CarInfo{Identifier: recalledCar.address, Position: &api.Coordinate{X: 1, Y: 1}, Depot: 1, VehicleType: utils.DefaultVehicleType, SeatCapacity: 4, AbortedTrips: []string{tr.id}})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for other.tripStops(tr.id) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the aborted trip to be dispatched to the other car")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if stops := recalledCar.tripStops(tr.id); stops != 0 {
+		t.Errorf("expected the recalled car to get no trips, got %d stops", stops)
+	}
+
+	handleCarUpdate(&api.CarInfo{Identifier: recalledCar.address, Position: depot, Depot: 1, VehicleType: utils.DefaultVehicleType, SeatCapacity: 4})
+	carinfoMutex.Lock()
+	stillRecalled := recalled[recalledCar.address]
+	carinfoMutex.Unlock()
+	if stillRecalled {
+		t.Error("expected the car to be back in service at its depot")
+	}
+}
+
+func isRecalled(identifier string) bool {
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+	return recalled[identifier]
+}
+
+func TestCarIsMarkedRecalledWhileTheRecallIsInFlight(t *testing.T) {
+	car := setupFleet(t)
+	var markedDuringCall bool
+	car.onRecall = func() { markedDuringCall = isRecalled(car.address) }
+
+	// A failed recall leaves the car in service
+	car.failures = 1
+	if err := recallCar(car.address); err == nil {
+		t.Fatal("expected the recall to fail")
+	}
+	if !markedDuringCall {
+		t.Error("car was not marked recalled while the recall was in flight")
+	}
+	if isRecalled(car.address) {
+		t.Error("car is still marked recalled after the recall failed")
+	}
+
+	if err := recallCar(car.address); err != nil {
+		t.Fatal(err)
+	}
+	if !isRecalled(car.address) {
+		t.Error("expected the car to be recalled")
+	}
+}
+
+func TestEmergencyStopRequeuesTripsOnResume(t *testing.T) {
+	car := setupFleet(t)
+	defer setFleetState(api.FleetState_RUNNING)
+
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	dispatchTrip(tr)
+
+	setFleetState(api.FleetState_EMERGENCY_STOPPED)
+	handleCarUpdate(&api.CarInfo{Identifier: car.address, Position: &api.Coordinate{X: 1, Y: 1}, VehicleType: utils.DefaultVehicleType, SeatCapacity: 4, ItineraryVersion: car.version, AbortedTrips: []string{tr.id}})
+	tripMutex.Lock()
+	assigned := tr.car
+	tripMutex.Unlock()
+	if assigned != "" {
+		t.Errorf("expected the trip to be taken away from %s", assigned)
+	}
+	if pending := pendingTrips(); pending != 1 {
+		t.Errorf("expected one pending trip while stopped, got %v", pending)
+	}
+
+	setFleetState(api.FleetState_RUNNING)
+	deadline := time.Now().Add(5 * time.Second)
+	for car.tripStops(tr.id) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the trip to be dispatched again after resuming")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// silence makes the car look like it stopped reporting.
+func silence(car *fakeCar) {
+	lastSeenMutex.Lock()
+	lastSeen[car.address] = time.Now().Add(-2 * onlineTimeout)
+	lastSeenMutex.Unlock()
+}
+
+func TestMissingRestoredCarIsDroppedAndSkipped(t *testing.T) {
+	car := setupFleet(t)
+	missing := startFakeCar(t)
+	addFakeCar(missing, &api.Coordinate{X: 1, Y: 2})
+	silence(missing)
+
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	dispatchTrip(tr)
+	if stops := missing.tripStops(tr.id); stops != 0 {
+		t.Errorf("expected the offline car to be skipped, got %d stops", stops)
+	}
+
+	// A trip restored with the missing car is taken back from it
+	restoredTrip := newTrip(&api.Coordinate{X: 2, Y: 3}, &api.Coordinate{X: 4, Y: 4}, 1, nil)
+	registerTrip(restoredTrip)
+	assignTrip(restoredTrip, missing.address, nil, 0)
+	restoredMutex.Lock()
+	restoredCars[missing.address] = true
+	restoredMutex.Unlock()
+
+	dropMissingCars()
+	carinfoMutex.Lock()
+	_, kept := carsByID[missing.address]
+	carinfoMutex.Unlock()
+	if kept {
+		t.Error("expected the missing car to be removed from the fleet view")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for car.tripStops(restoredTrip.id) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the trip of the missing car to be dispatched to the online car")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestOfflineCarIsRemovedFromTheFleetView(t *testing.T) {
+	car := setupFleet(t)
+	offline := startFakeCar(t)
+	addFakeCar(offline, &api.Coordinate{X: 1, Y: 2})
+	restored := startFakeCar(t)
+	addFakeCar(restored, &api.Coordinate{X: 2, Y: 1})
+	restoredMutex.Lock()
+	restoredCars[restored.address] = true
+	restoredMutex.Unlock()
+	t.Cleanup(func() {
+		restoredMutex.Lock()
+		delete(restoredCars, restored.address)
+		restoredMutex.Unlock()
+	})
+
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	assignTrip(tr, offline.address, nil, 0)
+	silence(offline)
+	silence(restored)
+
+	if dropped := dropOfflineCars(); len(dropped) != 1 || dropped[0] != offline.address {
+		t.Fatalf("expected only %s to be dropped, got %v", offline.address, dropped)
+	}
+	carinfoMutex.Lock()
+	_, kept := carsByID[offline.address]
+	_, restoredKept := carsByID[restored.address]
+	carinfoMutex.Unlock()
+	if kept {
+		t.Error("expected the offline car to be removed from the fleet view")
+	}
+	if !restoredKept {
+		t.Error("expected the restored car to be kept until it had the time to report back")
+	}
+	if nearby := carIndex.Query(&api.Coordinate{X: 1, Y: 2}, 0); contains(nearby, offline.address) {
+		t.Errorf("expected the offline car to be removed from the spatial index, got %v", nearby)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for car.tripStops(tr.id) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the trip of the offline car to be dispatched to the online car")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,161 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/utils"
+	"math"
+	"time"
+)
+
+const congestionDelay = 0.5 // extra time per cell for every other car around it, relative to the free-flow time
+
+// etaError accumulates how far actual arrivals deviate from the promised ones.
+type etaError struct {
+	count  int
+	sum    float64 // seconds, positive = later than promised
+	sumAbs float64
+}
+
+func (e *etaError) record(promised, actual time.Time) float64 {
+	deviation := actual.Sub(promised).Seconds()
+	e.count++
+	e.sum += deviation
+	e.sumAbs += math.Abs(deviation)
+	return deviation
+}
+
+// mean returns the average bias and the mean absolute error in seconds.
+func (e *etaError) mean() (float64, float64) {
+	if e.count == 0 {
+		return 0, 0
+	}
+	return e.sum / float64(e.count), e.sumAbs / float64(e.count)
+}
+
+var (
+	pickupError  etaError // guarded by tripMutex
+	dropoffError etaError // guarded by tripMutex
+)
+
+// stopKey identifies a stop of a trip across itinerary updates.
+type stopKey struct {
+	trip string
+	typ  api.StopType
+}
+
+// travelTime estimates the seconds needed to drive along path. Every other car
+// around a cell slows the car down by congestionDelay.
+func travelTime(identifier string, path []*api.Coordinate, speed float64) float64 {
+	seconds := 0.0
+	for i := 1; i < len(path); i++ {
+		traffic := 0
+		for _, id := range carIndex.Query(path[i], 1) {
+			if id != identifier {
+				traffic++
+			}
+		}
+		seconds += (1 + congestionDelay*float64(traffic)) / speed
+	}
+	return seconds
+}
+
+// stopETAs estimates when the car arrives at each stop of its itinerary.
+func stopETAs(carInfo *api.CarInfo) map[stopKey]time.Time {
+	speed := carInfo.MaxSpeed
+	if speed <= 0 {
+		speed = 1 // Car without motion model, one cell per second
+	}
+
+	seconds := 0.0
+	if exact := carInfo.ExactPosition; exact != nil {
+		// Rest of the edge the car is driving right now
+		seconds += (math.Abs(float64(carInfo.Position.X)-exact.X) + math.Abs(float64(carInfo.Position.Y)-exact.Y)) / speed
+	}
+
+	vehicle, _ := utils.LookupVehicleType(carInfo.VehicleType)
+	now := time.Now()
+	etas := make(map[stopKey]time.Time)
+	pos := carInfo.Position
+	for _, stop := range carInfo.Itinerary {
+		seconds += travelTime(carInfo.Identifier, utils.CalculatePathFor(pos, stop.Position, vehicle), speed)
+		pos = stop.Position
+		etas[stopKey{stop.TripId, stop.Type}] = now.Add(time.Duration(seconds * float64(time.Second)))
+	}
+	return etas
+}
+
+// updateETAs refreshes the ETAs of the trips served by the car. Stops which
+// disappeared from its itinerary were served, their actual arrival is
+// compared to the promised one.
+func updateETAs(carInfo *api.CarInfo) {
+	etas := stopETAs(carInfo)
+	aborted := make(map[string]bool)
+	for _, id := range carInfo.AbortedTrips {
+		aborted[id] = true
+	}
+	now := time.Now()
+
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+
+	for _, t := range trips {
+		if t.car != carInfo.Identifier || t.cancelled || aborted[t.id] || !t.droppedOff.IsZero() {
+			continue
+		}
+		pickupETA, waiting := etas[stopKey{t.id, api.StopType_PICKUP}]
+		dropoffETA, riding := etas[stopKey{t.id, api.StopType_DROPOFF}]
+		// A report of the acknowledged version counts even without the stops,
+		// the car may have served them while it could not reach the coordinator
+		if waiting || riding || (t.version > 0 && carInfo.ItineraryVersion >= t.version) {
+			t.seen = true
+		}
+		if !t.seen {
+			continue // Car did not acknowledge the itinerary yet
+		}
+
+		if waiting {
+			t.pickupETA = pickupETA
+		} else if t.pickedUp.IsZero() {
+			t.pickedUp = now
+			stateChanged()
+			deviation := pickupError.record(t.promisedPickup, now)
+			etaDeviation.WithLabelValues("pickup").Observe(deviation)
+			events.record(event{Type: eventTripPickedUp, Trip: t.id, Car: t.car})
+			bias, mae := pickupError.mean()
+			logging.Component("eta").Info("Picked up", logging.TripKey, t.id, logging.CarKey, t.car, "deviation", deviation, "bias", bias, "mean_error", mae, "pickups", pickupError.count)
+		}
+
+		if riding {
+			t.dropoffETA = dropoffETA
+		} else {
+			t.droppedOff = now
+			stateChanged()
+			shards.shareRoute(t.id, t.route, "", true)
+			deviation := dropoffError.record(t.promisedDropoff, now)
+			etaDeviation.WithLabelValues("dropoff").Observe(deviation)
+			tripDuration.Observe(now.Sub(t.requested).Seconds())
+			events.record(event{Type: eventTripCompleted, Trip: t.id, Car: t.car})
+			bias, mae := dropoffError.mean()
+			logging.Component("eta").Info("Dropped off", logging.TripKey, t.id, logging.CarKey, t.car, "deviation", deviation, "bias", bias, "mean_error", mae, "dropoffs", dropoffError.count)
+		}
+	}
+}
+
+// rideStatus reports the latest ETAs of a trip. Must be called with tripMutex held.
+func rideStatus(t *trip) *api.RideResponse {
+	untilNow := func(eta time.Time) float64 {
+		if eta.IsZero() {
+			return 0
+		}
+		return math.Max(time.Until(eta).Seconds(), 0)
+	}
+	return &api.RideResponse{
+		TripId:     t.id,
+		Car:        t.car,
+		PickupEta:  untilNow(t.pickupETA),
+		DropoffEta: untilNow(t.dropoffETA),
+		PickedUp:   !t.pickedUp.IsZero(),
+		Completed:  !t.droppedOff.IsZero(),
+	}
+}
@@ -0,0 +1,82 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/utils"
+	"testing"
+)
+
+// serveTrip assigns a trip to the car and reports the car driving through
+// its stops: pickup and dropoff pending, passengers on board, trip completed.
+func serveTrip(t *testing.T, car *fakeCar) *trip {
+	t.Helper()
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	dispatchTrip(tr)
+
+	car.mu.Lock()
+	stops := car.stops
+	car.mu.Unlock()
+	if len(stops) != 2 {
+		t.Fatalf("expected pickup and dropoff on the car, got %d stops", len(stops))
+	}
+	report := func(pos *api.Coordinate, itinerary []*api.Stop) {
+		handleCarUpdate(&api.CarInfo{Identifier: car.address, Position: pos, VehicleType: utils.DefaultVehicleType, SeatCapacity: 4, ActiveRoute: len(itinerary) > 0, Itinerary: itinerary})
+	}
+	report(&api.Coordinate{X: 1, Y: 2}, stops)
+	report(tr.pickup, stops[1:])
+	report(tr.dropoff, nil)
+	return tr
+}
+
+func TestUpdatesRecordPickupAndDropoff(t *testing.T) {
+	car := setupFleet(t)
+	tripMutex.Lock()
+	pickups, dropoffs := pickupError.count, dropoffError.count
+	tripMutex.Unlock()
+
+	tr := serveTrip(t, car)
+
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+	if tr.pickedUp.IsZero() || tr.droppedOff.IsZero() {
+		t.Fatalf("expected pickup and dropoff to be recorded, got %v and %v", tr.pickedUp, tr.droppedOff)
+	}
+	if status := rideStatus(tr); !status.PickedUp || !status.Completed {
+		t.Errorf("expected the ride to be completed, got %v", status)
+	}
+	if pickupError.count != pickups+1 || dropoffError.count != dropoffs+1 {
+		t.Errorf("expected one ETA error sample per stop, got %d pickups and %d dropoffs", pickupError.count-pickups, dropoffError.count-dropoffs)
+	}
+}
+
+func TestTripServedWhileUnreachableIsCompleted(t *testing.T) {
+	car := setupFleet(t)
+	tr := newTrip(&api.Coordinate{X: 1, Y: 3}, &api.Coordinate{X: 5, Y: 5}, 1, nil)
+	registerTrip(tr)
+	dispatchTrip(tr)
+
+	car.mu.Lock()
+	version := car.version
+	car.mu.Unlock()
+	report := func(version uint64) {
+		handleCarUpdate(&api.CarInfo{Identifier: car.address, Position: tr.dropoff, VehicleType: utils.DefaultVehicleType, SeatCapacity: 4, ItineraryVersion: version})
+	}
+
+	// Sent before the car received the itinerary
+	report(version - 1)
+	tripMutex.Lock()
+	early := rideStatus(tr)
+	tripMutex.Unlock()
+	if early.Completed {
+		t.Fatalf("trip completed by a report older than its itinerary: %v", early)
+	}
+
+	// The first report after reconnecting no longer has the served stops
+	report(version)
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+	if status := rideStatus(tr); !status.PickedUp || !status.Completed {
+		t.Errorf("expected the ride to be completed, got %v", status)
+	}
+}
@@ -0,0 +1,122 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Types of the events in the event log
+const (
+	eventCarRegistered  = "car_registered"
+	eventPositionUpdate = "position_update"
+	eventTripGenerated  = "trip_generated"
+	eventTripAssigned   = "trip_assigned"
+	eventTripPickedUp   = "trip_picked_up"
+	eventTripCompleted  = "trip_completed"
+	eventTripCancelled  = "trip_cancelled"
+	eventTripAborted    = "trip_aborted"
+	eventCollision      = "collision"
+	eventFleetState     = "fleet_state"
+	eventCarHandedOver  = "car_handed_over"
+)
+
+// event is one line of the event log. Only the fields of the event type are set.
+type event struct {
+	Seq          uint64            `json:"seq"`
+	Time         time.Time         `json:"time"`
+	Type         string            `json:"type"`
+	Car          string            `json:"car,omitempty"`
+	Trip         string            `json:"trip,omitempty"`
+	CarInfo      json.RawMessage   `json:"car_info,omitempty"` // protojson encoded CarInfo
+	Pickup       *api.Coordinate   `json:"pickup,omitempty"`
+	Dropoff      *api.Coordinate   `json:"dropoff,omitempty"`
+	Passengers   int32             `json:"passengers,omitempty"`
+	Requirements []string          `json:"requirements,omitempty"`
+	Route        []*api.Coordinate `json:"route,omitempty"`
+	Position     *api.Coordinate   `json:"position,omitempty"`
+	Other        string            `json:"other,omitempty"` // second car of a collision, new owner of a handed over car
+	State        string            `json:"state,omitempty"` // fleet state
+}
+
+// eventLog appends events as JSON lines to a file. Sequence numbers continue
+// where an existing log ended.
+type eventLog struct {
+	mu   sync.Mutex
+	file *os.File
+	seq  uint64
+}
+
+var events *eventLog // nil unless -eventLog is given
+
+func openEventLog(path string) (*eventLog, error) {
+	var last uint64
+	if file, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var e event
+			if json.Unmarshal(scanner.Bytes(), &e) == nil && e.Seq > last {
+				last = e.Seq
+			}
+		}
+		file.Close()
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLog{file: file, seq: last}, nil
+}
+
+// record stamps the event with the next sequence number and the current time
+// and appends it. Logging is best effort and never blocks the simulation on errors.
+func (l *eventLog) record(e event) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	e.Seq = l.seq
+	e.Time = time.Now().UTC()
+	line, err := json.Marshal(e)
+	if err != nil {
+		logging.Component("events").Warn("Failed to encode event", "type", e.Type, "err", err)
+		return
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		logging.Component("events").Warn("Failed to write event", "type", e.Type, "err", err)
+	}
+}
+
+// carEvent returns an event carrying the full CarInfo.
+func carEvent(eventType string, carInfo *api.CarInfo) event {
+	info, err := protojson.Marshal(carInfo)
+	if err != nil {
+		info = nil
+	}
+	return event{Type: eventType, Car: carInfo.Identifier, CarInfo: info}
+}
+
+// tripEvent returns an event describing a new trip.
+func tripEvent(eventType string, t *trip) event {
+	return event{
+		Type:         eventType,
+		Trip:         t.id,
+		Pickup:       t.pickup,
+		Dropoff:      t.dropoff,
+		Passengers:   t.passengers,
+		Requirements: t.requirements,
+		Route:        t.route.Coordinates,
+	}
+}
@@ -0,0 +1,60 @@
+package coordinator
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEventLogRecordsTripLifecycle(t *testing.T) {
+	car := setupFleet(t)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	log, err := openEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events = log
+	defer func() {
+		events = nil
+		log.file.Close()
+	}()
+
+	tr := serveTrip(t, car)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var last uint64
+	found := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid event line %q: %v", scanner.Text(), err)
+		}
+		if e.Seq != last+1 {
+			t.Errorf("expected sequence number %d, got %d", last+1, e.Seq)
+		}
+		last = e.Seq
+		if e.Trip == tr.id {
+			if e.Car != car.address {
+				t.Errorf("expected %s of %s by %s, got car %q", e.Type, tr.id, car.address, e.Car)
+			}
+			found[e.Type] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	for _, eventType := range []string{eventTripAssigned, eventTripPickedUp, eventTripCompleted} {
+		if !found[eventType] {
+			t.Errorf("expected a %s event for %s", eventType, tr.id)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/carclient"
+	"AutonomousCarFleetSimulation/coordinator/ha"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"crypto/ed25519"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// processEnv makes the test binary run a coordinator or car instead of the
+// tests, so the failover test can start and kill them as separate processes.
+const processEnv = "FLEET_TEST_PROCESS"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(processEnv) {
+	case "coordinator":
+		runProcess(Run)
+	case "car":
+		runProcess(carclient.Run)
+	}
+	os.Exit(m.Run())
+}
+
+// runProcess runs a main function with the flags given on the command line.
+func runProcess(run func()) {
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	run()
+	os.Exit(0)
+}
+
+// startProcess starts the test binary as coordinator or car and kills it at
+// the end of the test. Its log is shown if the test fails.
+func startProcess(t *testing.T, kind, name string, args ...string) *exec.Cmd {
+	t.Helper()
+	logPath := filepath.Join(t.TempDir(), name+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), processEnv+"="+kind)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		logFile.Close()
+		if t.Failed() {
+			if log, err := os.ReadFile(logPath); err == nil {
+				t.Logf("log of %s:\n%s", name, log)
+			}
+		}
+	})
+	return cmd
+}
+
+// freeAddresses returns localhost addresses of ports nobody listens on.
+func freeAddresses(t *testing.T, count int) []string {
+	t.Helper()
+	addresses := make([]string, count)
+	for i := range addresses {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addresses[i] = fmt.Sprintf("localhost:%d", listener.Addr().(*net.TCPAddr).Port)
+		listener.Close()
+	}
+	return addresses
+}
+
+func port(address string) string {
+	_, port, _ := net.SplitHostPort(address)
+	return port
+}
+
+// fleetClient talks to the coordinator replicas like an operator would.
+type fleetClient struct {
+	t       *testing.T
+	clients map[string]api.CoordinatorServiceClient
+	leader  string
+}
+
+func newFleetClient(t *testing.T, replicas []string, key ed25519.PrivateKey) *fleetClient {
+	token, err := security.NewIssuer(key).Issue(security.Claims{Subject: security.RoleOperator, Role: security.RoleOperator, Expires: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fleetClient{t: t, clients: make(map[string]api.CoordinatorServiceClient)}
+	for _, address := range replicas {
+		conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(security.TokenInterceptor(func() (string, error) { return token, nil })))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		f.clients[address] = api.NewCoordinatorServiceClient(conn)
+	}
+	f.leader = replicas[0]
+	return f
+}
+
+// requestRide sends a ride request to the leader, following redirects and
+// moving on to the next replica while none leads, until a car is assigned.
+func (f *fleetClient) requestRide(pickup, dropoff *api.Coordinate) *api.RideResponse {
+	f.t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		var trailer metadata.MD
+		resp, err := f.clients[f.leader].RequestRide(ctx, &api.RideRequest{Pickup: pickup, Dropoff: dropoff, Passengers: 1}, grpc.Trailer(&trailer))
+		cancel()
+		if err == nil && resp.Car != "" {
+			return resp
+		}
+		if target := ha.RedirectTarget(err, trailer); target != "" {
+			f.leader = target
+			continue
+		}
+		f.leader = f.next()
+		time.Sleep(200 * time.Millisecond)
+	}
+	f.t.Fatalf("no car was assigned to a ride from %v to %v", pickup, dropoff)
+	return nil
+}
+
+// next returns another replica than the current leader.
+func (f *fleetClient) next() string {
+	for address := range f.clients {
+		if address != f.leader {
+			return address
+		}
+	}
+	return f.leader
+}
+
+// completed reports whether any replica saw the trip finish.
+func (f *fleetClient) completed(id string) bool {
+	for _, client := range f.clients {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		resp, err := client.GetTripEta(ctx, &api.TripRequest{TripId: id})
+		cancel()
+		if err == nil && resp.Completed {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCarsAndTripsSurviveLeaderFailover(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts coordinator and car processes")
+	}
+	keyPath := filepath.Join(t.TempDir(), "signing.key")
+	key, err := security.LoadOrCreateKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replicas := freeAddresses(t, 3)
+	coordinators := make(map[string]*exec.Cmd)
+	for i, address := range replicas {
+		coordinators[address] = startProcess(t, "coordinator", fmt.Sprintf("coordinator-%d", i),
+			"-port="+port(address), "-replicas="+strings.Join(replicas, ","), "-signingKey="+keyPath,
+			"-raftState="+filepath.Join(t.TempDir(), "raft.state"),
+			"-replicationInterval=200ms", "-metrics=", "-logLevel=warn")
+	}
+	for i, address := range freeAddresses(t, 2) {
+		startProcess(t, "car", fmt.Sprintf("car-%d", i),
+			"-port="+port(address), fmt.Sprintf("-x=%d", 1+2*i), "-y=1", "-coordinators="+strings.Join(replicas, ","),
+			"-idleTimeout=0", "-maxSpeed=4", "-logLevel=warn")
+	}
+
+	fleet := newFleetClient(t, replicas, key)
+	rides := []*api.RideResponse{
+		fleet.requestRide(&api.Coordinate{X: 2, Y: 1}, &api.Coordinate{X: 2, Y: 13}),
+		fleet.requestRide(&api.Coordinate{X: 3, Y: 2}, &api.Coordinate{X: 13, Y: 2}),
+	}
+	for _, ride := range rides {
+		if fleet.completed(ride.TripId) {
+			t.Fatalf("trip %s finished before the leader was killed", ride.TripId)
+		}
+	}
+
+	// The rides were acknowledged, so the other replicas know the trips. Kill
+	// the leader while the cars are serving them.
+	leader := coordinators[fleet.leader]
+	leader.Process.Kill()
+	leader.Wait()
+	delete(fleet.clients, fleet.leader)
+
+	// Pickups and dropoffs are only recorded from the reports of the cars,
+	// so finished trips show that the cars found the new leader
+	for _, ride := range rides {
+		deadline := time.Now().Add(60 * time.Second)
+		for !fleet.completed(ride.TripId) {
+			if time.Now().After(deadline) {
+				t.Fatalf("trip %s of %s did not finish after the failover", ride.TripId, ride.Car)
+			}
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"sync"
+	"time"
+)
+
+var (
+	fleetState = api.FleetState_RUNNING
+	fleetMutex sync.Mutex
+)
+
+func fleetRunning() bool {
+	fleetMutex.Lock()
+	defer fleetMutex.Unlock()
+	return fleetState == api.FleetState_RUNNING
+}
+
+// fleetCommand returns the command which brings a car into the given fleet state.
+func fleetCommand(state api.FleetState) *api.Command {
+	switch state {
+	case api.FleetState_PAUSED:
+		return &api.Command{Type: api.CommandType_PAUSE}
+	case api.FleetState_EMERGENCY_STOPPED:
+		return &api.Command{Type: api.CommandType_EMERGENCY_STOP}
+	default:
+		return &api.Command{Type: api.CommandType_RESUME}
+	}
+}
+
+// setFleetState pauses, resumes or emergency stops the whole fleet. Route
+// generation and dispatching only continue while the fleet is running.
+func setFleetState(state api.FleetState) {
+	fleetMutex.Lock()
+	fleetState = state
+	fleetMutex.Unlock()
+	stateChanged()
+	logging.Component("fleet").Info("Fleet state changed", "state", state)
+	events.record(event{Type: eventFleetState, State: state.String()})
+	if state == api.FleetState_EMERGENCY_STOPPED {
+		abortAssignedTrips()
+	}
+
+	carinfoMutex.Lock()
+	identifiers := make([]string, 0, len(carinfos))
+	for _, car := range carinfos {
+		identifiers = append(identifiers, car.Identifier)
+	}
+	carinfoMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, identifier := range identifiers {
+		wg.Add(1)
+		go func(identifier string) {
+			defer wg.Done()
+			if _, err := sendCommand(identifier, fleetCommand(state)); err != nil {
+				logging.Component("fleet").Warn("Failed to send fleet state", logging.CarKey, identifier, "state", state, "err", err)
+			}
+		}(identifier)
+	}
+	wg.Wait()
+}
+
+// abortAssignedTrips takes the open trips away from their cars, which drop
+// their itineraries on an emergency stop. The trips are dispatched again once
+// the fleet resumes.
+func abortAssignedTrips() {
+	tripMutex.Lock()
+	var aborted []*trip
+	for _, t := range trips {
+		if t.car != "" && !t.cancelled && t.droppedOff.IsZero() {
+			events.record(event{Type: eventTripAborted, Trip: t.id, Car: t.car})
+			unassign(t)
+			aborted = append(aborted, t)
+		}
+	}
+	tripMutex.Unlock()
+
+	carinfoMutex.Lock()
+	for _, t := range aborted {
+		updateGridDataRoute(t.route, "")
+	}
+	carinfoMutex.Unlock()
+
+	for _, t := range aborted {
+		go dispatchTrip(t) // Waits until the fleet is running again
+	}
+	logging.Component("fleet").Warn("Trips aborted by the emergency stop", "trips", len(aborted))
+}
+
+// syncFleetState brings a newly registered car into the current fleet state.
+func syncFleetState(identifier string) {
+	fleetMutex.Lock()
+	state := fleetState
+	fleetMutex.Unlock()
+
+	if state == api.FleetState_RUNNING {
+		return
+	}
+	if _, err := sendCommand(identifier, fleetCommand(state)); err != nil {
+		logging.Component("fleet").Warn("Failed to send fleet state", logging.CarKey, identifier, "state", state, "err", err)
+	}
+}
+
+// expireOfflineCars periodically drops the cars which stopped reporting while
+// leading.
+func expireOfflineCars() {
+	ticker := time.NewTicker(onlineTimeout)
+	defer ticker.Stop()
+	for range ticker.C {
+		if leading() {
+			dropOfflineCars()
+		}
+	}
+}
+
+// dropOfflineCars removes the cars whose last report is older than
+// onlineTimeout from the fleet view, so nearby-car queries, collision checks
+// and ETAs only see online cars, and dispatches their trips again. Restored
+// cars have until reconcileTimeout to report back. It returns the dropped cars.
+func dropOfflineCars() []string {
+	online := onlineCars()
+	carinfoMutex.Lock()
+	restoredMutex.Lock()
+	var offline []string
+	for _, car := range carinfos {
+		if !online[car.Identifier] && !restoredCars[car.Identifier] {
+			offline = append(offline, car.Identifier)
+		}
+	}
+	restoredMutex.Unlock()
+	carinfoMutex.Unlock()
+
+	for _, identifier := range offline {
+		logging.Component("fleet").Warn("Car went offline", logging.CarKey, identifier)
+		removeCar(identifier)
+		requeueTrips(identifier)
+	}
+	return offline
+}
@@ -1,11 +1,13 @@
 package coordinator
 
 import (
+	"AutonomousCarFleetSimulation/api"
 	"AutonomousCarFleetSimulation/utils"
 	"image/color"
 
 	"gioui.org/app"
 	"gioui.org/font"
+	"gioui.org/io/key"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/paint"
@@ -13,7 +15,9 @@ import (
 	"gioui.org/widget/material"
 )
 
-func display(window *app.Window) error {
+// display draws the grid and passes key presses to the shortcut handler, which
+// controls the live fleet or a replay.
+func display(window *app.Window, shortcuts func(layout.Context, *app.Window)) error {
 	window.Option(app.Size(2500, 2500))
 
 	theme := material.NewTheme()
@@ -32,6 +36,8 @@ func display(window *app.Window) error {
 		case app.FrameEvent:
 			gtx := app.NewContext(&ops, e)
 
+			shortcuts(gtx, window)
+
 			// Set the entire background to light grey
 			paint.ColorOp{Color: lightBlack}.Add(gtx.Ops)
 			paint.PaintOp{}.Add(gtx.Ops)
@@ -42,6 +48,43 @@ func display(window *app.Window) error {
 	}
 }
 
+// handleShortcuts controls the fleet from the keyboard: P pauses, R resumes
+// and E or Escape triggers an emergency stop.
+func handleShortcuts(gtx layout.Context, window *app.Window) {
+	for {
+		ev, ok := gtx.Event(
+			key.Filter{Name: "P"},
+			key.Filter{Name: "R"},
+			key.Filter{Name: "E"},
+			key.Filter{Name: key.NameEscape},
+		)
+		if !ok {
+			return
+		}
+		e, ok := ev.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+
+		if !leading() {
+			window.Option(app.Title("Fleet commands only work on the leader " + replication.Leader()))
+			continue
+		}
+
+		var state api.FleetState
+		switch e.Name {
+		case "P":
+			state = api.FleetState_PAUSED
+		case "R":
+			state = api.FleetState_RUNNING
+		default:
+			state = api.FleetState_EMERGENCY_STOPPED
+		}
+		window.Option(app.Title("Fleet " + state.String()))
+		go setFleetState(state)
+	}
+}
+
 func drawGrid(gtx layout.Context, th *material.Theme) layout.Dimensions {
 	var rows []layout.FlexChild
 	for _, rowData := range gridData {
@@ -84,6 +127,12 @@ func drawRow(gtx layout.Context, th *material.Theme, data [][2]string) layout.Di
 				col = color.NRGBA{R: 128, G: 0, B: 128, A: 255} // Lila
 			case "Braun":
 				col = color.NRGBA{R: 165, G: 42, B: 42, A: 255} // Braun
+			case "Depot":
+				col = color.NRGBA{R: 200, G: 200, B: 200, A: 255} // Hellgrau
+			case "Charger":
+				col = color.NRGBA{R: 255, G: 255, B: 0, A: 255} // Gelb
+			case "Pedestrian":
+				col = color.NRGBA{R: 144, G: 238, B: 144, A: 255} // Hellgrün
 			default:
 				col = color.NRGBA{R: 0, G: 0, B: 0, A: 255} // Schwarz
 			}
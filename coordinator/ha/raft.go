@@ -0,0 +1,731 @@
+// Package ha replicates the coordinator between several replicas. It is a
+// compact Raft: the replicas elect a leader, the leader appends entries to
+// its log and replicates them, and an entry is committed once a majority
+// stored it. A leader which loses contact to the majority steps down after an
+// election timeout, so a partitioned leader stops acting about when the other
+// replicas elect a new one.
+//
+// Every entry carries the full coordinator state, so a replica only needs the
+// newest committed entry. The log is compacted down to it and followers which
+// fell behind get it as a snapshot. The current term and the vote are written
+// to the file given to Persist before a replica votes or campaigns, so a
+// restarted replica cannot vote twice in a term and each term has at most one
+// leader. The log is kept in memory: a restarted replica rejoins with an empty
+// log and catches up from the leader, so committed states survive as long as
+// a majority of the replicas keeps running.
+package ha
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	heartbeatInterval  = 100 * time.Millisecond
+	minElectionTimeout = 500 * time.Millisecond
+	maxElectionTimeout = 1000 * time.Millisecond
+	rpcTimeout         = 500 * time.Millisecond
+
+	LeaderKey = "leader" // trailer naming the coordinator to use instead when a call is redirected
+)
+
+var ErrNotLeader = errors.New("not the leader")
+
+type Role int
+
+const (
+	Follower Role = iota
+	Candidate
+	Leader
+)
+
+func (r Role) String() string {
+	switch r {
+	case Candidate:
+		return "candidate"
+	case Leader:
+		return "leader"
+	default:
+		return "follower"
+	}
+}
+
+// StateMachine is the replicated state. The callbacks are called one at a
+// time from a single goroutine.
+type StateMachine interface {
+	// Apply loads the newest committed state on a follower. Intermediate
+	// states may be skipped. A new leader gets the newest state of its log
+	// right before Lead.
+	Apply(data []byte)
+	// Lead is called when the replica became leader.
+	Lead()
+	// Follow is called when the replica lost the leadership.
+	Follow(leader string)
+}
+
+// Node is one replica.
+type Node struct {
+	api.ReplicaServiceServer
+
+	mu       sync.Mutex
+	id       string
+	peers    []string
+	clients  map[string]api.ReplicaServiceClient
+	conns    []*grpc.ClientConn
+	sm       StateMachine
+	role     Role
+	term     uint64
+	votedFor string
+	persist  string // file term and votedFor are kept in, empty = memory only
+	leader   string
+	votes    int
+	entries  []*api.LogEntry // entries[0] is the entry the log was compacted to
+	commit   uint64
+	applied  uint64
+	next     map[string]uint64        // per peer: index of the next entry to send
+	match    map[string]uint64        // per peer: highest index known to be replicated
+	contact  map[string]time.Time     // per peer: when the last request the peer answered was sent
+	wake     map[string]chan struct{} // per peer: replicate new entries right away
+	deadline time.Time                // start an election if the leader is silent until then
+	notify   chan struct{}            // wakes up the apply loop
+	stop     chan struct{}
+	log      *slog.Logger
+}
+
+// NewNode creates the replica id. peers are the addresses of the other replicas.
+func NewNode(id string, peers []string, sm StateMachine) *Node {
+	n := &Node{
+		id:      id,
+		clients: make(map[string]api.ReplicaServiceClient),
+		sm:      sm,
+		entries: []*api.LogEntry{{}},
+		next:    make(map[string]uint64),
+		match:   make(map[string]uint64),
+		contact: make(map[string]time.Time),
+		wake:    make(map[string]chan struct{}),
+		notify:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		log:     logging.Component("ha").With("replica", id),
+	}
+	for _, peer := range peers {
+		if peer != "" && peer != id {
+			n.peers = append(n.peers, peer)
+			n.wake[peer] = make(chan struct{}, 1)
+		}
+	}
+	return n
+}
+
+// Register adds the replication service to the gRPC server of the replica.
+func (n *Node) Register(server *grpc.Server) {
+	api.RegisterReplicaServiceServer(server, n)
+}
+
+// termState is the content of the file given to Persist.
+type termState struct {
+	Term     uint64 `json:"term"`
+	VotedFor string `json:"votedFor,omitempty"`
+}
+
+// Persist keeps the term and vote in the file at path and restores them from
+// it. It must be called before Start.
+func (n *Node) Persist(path string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	data, err := os.ReadFile(path)
+	if err == nil {
+		var state termState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return err
+		}
+		n.term, n.votedFor = state.Term, state.VotedFor
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	n.persist = path
+	return nil
+}
+
+// saveTerm writes term and vote to the file given to Persist and replaces the
+// file atomically. Must be called with n.mu held.
+func (n *Node) saveTerm(term uint64, votedFor string) error {
+	if n.persist == "" {
+		return nil
+	}
+	data, err := json.Marshal(termState{Term: term, VotedFor: votedFor})
+	if err != nil {
+		return err
+	}
+	tmp := n.persist + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, n.persist)
+}
+
+// Start connects to the peers and takes part in elections. The options are
+// added to the connections to the peers.
+func (n *Node) Start(options ...grpc.DialOption) error {
+	for _, peer := range n.peers {
+		conn, err := grpc.Dial(peer, append([]grpc.DialOption{security.DialOption()}, options...)...)
+		if err != nil {
+			return err
+		}
+		n.conns = append(n.conns, conn)
+		n.clients[peer] = api.NewReplicaServiceClient(conn)
+	}
+
+	n.mu.Lock()
+	n.resetDeadline()
+	n.mu.Unlock()
+
+	go n.run()
+	go n.applyLoop()
+	return nil
+}
+
+// Stop leaves the group. The node does not answer or send any requests afterwards.
+func (n *Node) Stop() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	select {
+	case <-n.stop:
+		return
+	default:
+	}
+	close(n.stop)
+	n.role = Follower
+	for _, conn := range n.conns {
+		conn.Close()
+	}
+}
+
+func (n *Node) stopped() bool {
+	select {
+	case <-n.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (n *Node) ID() string {
+	return n.id
+}
+
+func (n *Node) IsLeader() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.role == Leader
+}
+
+// Leader returns the address of the current leader, empty while unknown.
+func (n *Node) Leader() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.leader
+}
+
+func (n *Node) Term() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.term
+}
+
+// Propose appends a new state to the log of the leader and replicates it
+// right away.
+func (n *Node) Propose(data []byte) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err := n.appendEntry(data)
+	return err
+}
+
+// Commit proposes a new state and waits until a majority stored it. An error
+// means the state may be lost, e.g. because the node lost the leadership.
+func (n *Node) Commit(ctx context.Context, data []byte) error {
+	n.mu.Lock()
+	index, err := n.appendEntry(data)
+	term := n.term
+	n.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(heartbeatInterval / 10)
+	defer ticker.Stop()
+	for {
+		n.mu.Lock()
+		leading := n.role == Leader && n.term == term
+		committed := n.commit >= index
+		n.mu.Unlock()
+		if !leading {
+			return ErrNotLeader
+		}
+		if committed {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// appendEntry must be called with n.mu held.
+func (n *Node) appendEntry(data []byte) (uint64, error) {
+	if n.role != Leader {
+		return 0, ErrNotLeader
+	}
+	n.entries = append(n.entries, &api.LogEntry{Term: n.term, Index: n.lastIndex() + 1, Data: data})
+	n.advanceCommit()
+	for _, wake := range n.wake {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+	return n.lastIndex(), nil
+}
+
+// Redirect rejects a call on a replica which is not the leader. The leader is
+// named in the trailer, see RedirectTarget.
+func (n *Node) Redirect(ctx context.Context) error {
+	return RedirectTo(ctx, n.Leader(), n.id+" is not the leader")
+}
+
+// RedirectTo rejects a call and names the coordinator which handles it
+// instead, if known.
+func RedirectTo(ctx context.Context, target, reason string) error {
+	if target != "" {
+		grpc.SetTrailer(ctx, metadata.Pairs(LeaderKey, target))
+	}
+	return status.Errorf(codes.FailedPrecondition, "%s, use %q", reason, target)
+}
+
+// RedirectTarget returns the coordinator named by a rejected call, or an
+// empty string.
+func RedirectTarget(err error, trailer metadata.MD) string {
+	if status.Code(err) != codes.FailedPrecondition {
+		return ""
+	}
+	if leader := trailer.Get(LeaderKey); len(leader) > 0 {
+		return leader[0]
+	}
+	return ""
+}
+
+func (n *Node) lastIndex() uint64 {
+	return n.entries[len(n.entries)-1].Index
+}
+
+// entry returns the entry at index, which must not be compacted.
+func (n *Node) entry(index uint64) *api.LogEntry {
+	return n.entries[index-n.entries[0].Index]
+}
+
+func (n *Node) quorum() int {
+	return (len(n.peers)+1)/2 + 1
+}
+
+// hasQuorum reports whether a majority answered requests the leader sent
+// within the last minimum election timeout. Followers wait at least that long
+// before electing another leader, so a leader cut off from the majority
+// steps down before a new one can be elected. Must be called with n.mu held.
+func (n *Node) hasQuorum() bool {
+	reached := 1
+	for _, peer := range n.peers {
+		if time.Since(n.contact[peer]) < minElectionTimeout {
+			reached++
+		}
+	}
+	return reached >= n.quorum()
+}
+
+func (n *Node) resetDeadline() {
+	timeout := minElectionTimeout + time.Duration(rand.Int63n(int64(maxElectionTimeout-minElectionTimeout)))
+	n.deadline = time.Now().Add(timeout)
+}
+
+func (n *Node) wakeApplier() {
+	select {
+	case n.notify <- struct{}{}:
+	default:
+	}
+}
+
+// becomeFollower steps down or follows a new leader. Must be called with n.mu held.
+func (n *Node) becomeFollower(term uint64, leader string) {
+	if term > n.term {
+		// Without the new term on disk the old one is restored after a restart,
+		// which is safe as long as no vote of the new term was given
+		if err := n.saveTerm(term, ""); err != nil {
+			n.log.Warn("Failed to save term", "term", term, "err", err)
+		}
+		n.term = term
+		n.votedFor = ""
+		n.leader = ""
+	}
+	if leader != "" {
+		n.leader = leader
+	}
+	if n.role == Leader {
+		n.applied = min(n.applied, n.commit) // Uncommitted states of the old term may be replaced
+	}
+	if n.role != Follower {
+		n.log.Info("Following", "term", n.term, "leader", n.leader)
+		n.wakeApplier()
+	}
+	n.role = Follower
+	n.resetDeadline()
+}
+
+// becomeLeader must be called with n.mu held.
+func (n *Node) becomeLeader() {
+	n.role = Leader
+	n.leader = n.id
+	for _, peer := range n.peers {
+		n.next[peer] = n.lastIndex() + 1
+		n.match[peer] = 0
+		n.contact[peer] = time.Now() // The votes count as contact
+	}
+	// Entries of earlier terms only commit together with one of the current
+	// term, so the term starts with a copy of the newest state.
+	last := n.entries[len(n.entries)-1]
+	n.entries = append(n.entries, &api.LogEntry{Term: n.term, Index: last.Index + 1, Data: last.Data})
+	n.advanceCommit()
+	n.log.Info("Elected leader", "term", n.term)
+
+	for _, peer := range n.peers {
+		go n.replicate(peer, n.term)
+	}
+	n.wakeApplier()
+}
+
+// run starts an election whenever the leader was silent for an election
+// timeout and lets a leader step down once it lost contact to the majority.
+func (n *Node) run() {
+	ticker := time.NewTicker(heartbeatInterval / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+		}
+
+		n.mu.Lock()
+		switch {
+		case n.role == Leader && !n.hasQuorum():
+			// Partitioned from the majority, which elects another leader
+			n.log.Warn("Lost contact to the majority, stepping down", "term", n.term)
+			n.leader = ""
+			n.becomeFollower(n.term, "")
+		case n.role != Leader && time.Now().After(n.deadline):
+			n.campaign()
+		}
+		n.mu.Unlock()
+	}
+}
+
+// campaign asks all peers for their votes. Must be called with n.mu held.
+func (n *Node) campaign() {
+	if err := n.saveTerm(n.term+1, n.id); err != nil {
+		n.log.Warn("Failed to save term, not campaigning", "term", n.term+1, "err", err)
+		n.resetDeadline()
+		return
+	}
+	n.term++
+	n.role = Candidate
+	n.votedFor = n.id
+	n.leader = ""
+	n.votes = 1
+	n.resetDeadline()
+	n.log.Debug("Starting election", "term", n.term)
+
+	last := n.entries[len(n.entries)-1]
+	req := &api.VoteRequest{Term: n.term, Candidate: n.id, LastLogIndex: last.Index, LastLogTerm: last.Term}
+	if n.votes >= n.quorum() {
+		n.becomeLeader()
+		return
+	}
+	for _, peer := range n.peers {
+		go n.requestVote(n.clients[peer], req)
+	}
+}
+
+func (n *Node) requestVote(client api.ReplicaServiceClient, req *api.VoteRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	resp, err := client.RequestVote(ctx, req)
+	if err != nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped() {
+		return
+	}
+	if resp.Term > n.term {
+		n.becomeFollower(resp.Term, "")
+		return
+	}
+	if n.role != Candidate || n.term != req.Term || !resp.Granted {
+		return
+	}
+	n.votes++
+	if n.votes >= n.quorum() {
+		n.becomeLeader()
+	}
+}
+
+// replicate sends heartbeats with the missing entries to a peer for as long
+// as the node leads in term.
+func (n *Node) replicate(peer string, term uint64) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for n.sendAppend(peer, term) {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+		case <-n.wake[peer]:
+		}
+	}
+}
+
+// sendAppend sends one AppendEntries request and reports whether the node
+// still leads in term.
+func (n *Node) sendAppend(peer string, term uint64) bool {
+	n.mu.Lock()
+	if n.role != Leader || n.term != term || n.stopped() {
+		n.mu.Unlock()
+		return false
+	}
+	next := n.next[peer]
+	base := n.entries[0]
+	req := &api.AppendRequest{Term: term, Leader: n.id, LeaderCommit: n.commit}
+	if next <= base.Index {
+		// The entries the peer misses were compacted, send the whole log
+		req.Snapshot = true
+		req.Entries = append([]*api.LogEntry(nil), n.entries...)
+	} else {
+		prev := n.entry(next - 1)
+		req.PrevLogIndex, req.PrevLogTerm = prev.Index, prev.Term
+		req.Entries = append([]*api.LogEntry(nil), n.entries[next-base.Index:]...)
+	}
+	client := n.clients[peer]
+	n.mu.Unlock()
+
+	sent := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	resp, err := client.AppendEntries(ctx, req)
+	if err != nil {
+		n.log.Debug("Peer unreachable", "peer", peer, "err", err)
+		return true
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if resp.Term > n.term {
+		n.becomeFollower(resp.Term, "")
+		return false
+	}
+	if n.role != Leader || n.term != term {
+		return false
+	}
+	if sent.After(n.contact[peer]) {
+		n.contact[peer] = sent
+	}
+	if resp.Success {
+		n.match[peer] = max(n.match[peer], resp.MatchIndex)
+		n.next[peer] = n.match[peer] + 1
+		n.advanceCommit()
+	} else {
+		n.next[peer] = max(1, min(next-1, resp.MatchIndex+1))
+	}
+	return true
+}
+
+// advanceCommit commits the newest entry of the current term which a majority
+// stored. Must be called with n.mu held.
+func (n *Node) advanceCommit() {
+	for index := n.lastIndex(); index > n.commit; index-- {
+		if n.entry(index).Term != n.term {
+			return // Older entries are committed indirectly
+		}
+		replicas := 1
+		for _, match := range n.match {
+			if match >= index {
+				replicas++
+			}
+		}
+		if replicas >= n.quorum() {
+			n.commit = index
+			n.wakeApplier()
+			return
+		}
+	}
+}
+
+func (n *Node) RequestVote(ctx context.Context, req *api.VoteRequest) (*api.VoteResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped() {
+		return nil, status.Error(codes.Unavailable, "replica stopped")
+	}
+
+	if req.Term > n.term {
+		n.becomeFollower(req.Term, "")
+	}
+	resp := &api.VoteResponse{Term: n.term}
+	if req.Term < n.term || (n.votedFor != "" && n.votedFor != req.Candidate) {
+		return resp, nil
+	}
+	// Only vote for candidates whose log is at least as up to date
+	last := n.entries[len(n.entries)-1]
+	if req.LastLogTerm < last.Term || (req.LastLogTerm == last.Term && req.LastLogIndex < last.Index) {
+		return resp, nil
+	}
+	if err := n.saveTerm(n.term, req.Candidate); err != nil {
+		n.log.Warn("Failed to save vote, not voting", "term", n.term, "err", err)
+		return resp, nil
+	}
+	n.votedFor = req.Candidate
+	n.resetDeadline()
+	resp.Granted = true
+	return resp, nil
+}
+
+func (n *Node) AppendEntries(ctx context.Context, req *api.AppendRequest) (*api.AppendResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped() {
+		return nil, status.Error(codes.Unavailable, "replica stopped")
+	}
+
+	resp := &api.AppendResponse{Term: n.term}
+	if req.Term < n.term {
+		return resp, nil
+	}
+	n.becomeFollower(req.Term, req.Leader)
+	resp.Term = n.term
+
+	if req.Snapshot {
+		if len(req.Entries) == 0 {
+			return resp, nil
+		}
+		n.entries = req.Entries
+		n.commit = max(n.commit, n.entries[0].Index, min(req.LeaderCommit, n.lastIndex()))
+		n.wakeApplier()
+		resp.Success, resp.MatchIndex = true, n.lastIndex()
+		return resp, nil
+	}
+
+	base := n.entries[0]
+	if req.PrevLogIndex > n.lastIndex() {
+		resp.MatchIndex = n.lastIndex()
+		return resp, nil
+	}
+	if req.PrevLogIndex >= base.Index && n.entry(req.PrevLogIndex).Term != req.PrevLogTerm {
+		resp.MatchIndex = n.commit // Committed entries always match
+		return resp, nil
+	}
+
+	for _, e := range req.Entries {
+		if e.Index <= base.Index {
+			continue // Compacted entries are committed and therefore equal
+		}
+		if e.Index <= n.lastIndex() {
+			if n.entry(e.Index).Term == e.Term {
+				continue
+			}
+			n.entries = n.entries[:e.Index-base.Index] // Conflict: drop the entry and all following ones
+		}
+		n.entries = append(n.entries, e)
+	}
+	matched := req.PrevLogIndex + uint64(len(req.Entries))
+	n.commit = max(n.commit, min(req.LeaderCommit, matched))
+	n.wakeApplier()
+	resp.Success, resp.MatchIndex = true, matched
+	return resp, nil
+}
+
+// applyLoop hands committed states and changes of the leadership to the state machine.
+func (n *Node) applyLoop() {
+	leading := false
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-n.notify:
+		}
+
+		n.mu.Lock()
+		isLeader := n.role == Leader
+		leader := n.leader
+		var data []byte
+		target := n.commit
+		if isLeader && !leading {
+			target = n.lastIndex() // A new leader continues from the newest state in its log
+		}
+		if target > n.applied && (!isLeader || !leading) {
+			data = n.entry(target).Data
+		}
+		n.applied = max(n.applied, target)
+		n.compact()
+		n.mu.Unlock()
+
+		if len(data) > 0 {
+			n.sm.Apply(data)
+		}
+		if isLeader != leading {
+			leading = isLeader
+			if leading {
+				n.sm.Lead()
+			} else {
+				n.sm.Follow(leader)
+			}
+		}
+	}
+}
+
+// compact drops all entries before the newest applied and committed one.
+// Must be called with n.mu held.
+func (n *Node) compact() {
+	upto := min(n.applied, n.commit)
+	if upto > n.entries[0].Index {
+		n.entries = append([]*api.LogEntry(nil), n.entries[upto-n.entries[0].Index:]...)
+	}
+}
@@ -0,0 +1,262 @@
+package ha
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"context"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// recorder is a StateMachine which remembers the last applied state.
+type recorder struct {
+	mu      sync.Mutex
+	state   string
+	leading bool
+}
+
+func (r *recorder) Apply(data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state = string(data)
+}
+
+func (r *recorder) Lead() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leading = true
+}
+
+func (r *recorder) Follow(leader string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leading = false
+}
+
+func (r *recorder) get() (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state, r.leading
+}
+
+type replica struct {
+	node   *Node
+	server *grpc.Server
+	state  *recorder
+}
+
+func startReplicas(t *testing.T, count int) []*replica {
+	t.Helper()
+	listeners := make([]net.Listener, count)
+	addresses := make([]string, count)
+	for i := range listeners {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		listeners[i] = listener
+		addresses[i] = listener.Addr().String()
+	}
+
+	replicas := make([]*replica, count)
+	for i := range replicas {
+		r := &replica{server: grpc.NewServer(), state: &recorder{}}
+		r.node = NewNode(addresses[i], addresses, r.state)
+		r.node.Register(r.server)
+		go r.server.Serve(listeners[i])
+		if err := r.node.Start(); err != nil {
+			t.Fatal(err)
+		}
+		replicas[i] = r
+	}
+	t.Cleanup(func() {
+		for _, r := range replicas {
+			r.kill()
+		}
+	})
+	return replicas
+}
+
+func (r *replica) kill() {
+	r.node.Stop()
+	r.server.Stop()
+}
+
+// waitFor polls condition until it holds or the timeout expires.
+func waitFor(t *testing.T, timeout time.Duration, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// leaderOf returns the only leader among the replicas, or nil.
+func leaderOf(replicas []*replica) *replica {
+	var leader *replica
+	for _, r := range replicas {
+		if r.node.IsLeader() {
+			if leader != nil {
+				return nil
+			}
+			leader = r
+		}
+	}
+	return leader
+}
+
+func without(replicas []*replica, removed *replica) []*replica {
+	var result []*replica
+	for _, r := range replicas {
+		if r != removed {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func TestLeaderFailover(t *testing.T) {
+	replicas := startReplicas(t, 3)
+
+	var leader *replica
+	waitFor(t, 5*time.Second, "a leader", func() bool {
+		leader = leaderOf(replicas)
+		return leader != nil
+	})
+	waitFor(t, time.Second, "the Lead callback", func() bool {
+		_, leading := leader.state.get()
+		return leading
+	})
+
+	if err := leader.node.Propose([]byte("state 1")); err != nil {
+		t.Fatal(err)
+	}
+	followers := without(replicas, leader)
+	waitFor(t, 5*time.Second, "replication to the followers", func() bool {
+		for _, r := range followers {
+			if state, _ := r.state.get(); state != "state 1" {
+				return false
+			}
+		}
+		return true
+	})
+	for _, r := range followers {
+		if err := r.node.Propose([]byte("rejected")); err != ErrNotLeader {
+			t.Errorf("Propose on follower %s returned %v, want ErrNotLeader", r.node.ID(), err)
+		}
+		if got := r.node.Leader(); got != leader.node.ID() {
+			t.Errorf("follower %s follows %q, want %q", r.node.ID(), got, leader.node.ID())
+		}
+	}
+
+	// Kill the leader mid-run, the remaining majority elects a new one which
+	// continues from the replicated state
+	oldTerm := leader.node.Term()
+	leader.kill()
+
+	var newLeader *replica
+	waitFor(t, 5*time.Second, "a new leader", func() bool {
+		newLeader = leaderOf(followers)
+		return newLeader != nil
+	})
+	if newLeader.node.Term() <= oldTerm {
+		t.Errorf("new leader has term %d, want more than %d", newLeader.node.Term(), oldTerm)
+	}
+	waitFor(t, time.Second, "the Lead callback of the new leader", func() bool {
+		_, leading := newLeader.state.get()
+		return leading
+	})
+	if state, _ := newLeader.state.get(); state != "state 1" {
+		t.Errorf("new leader starts from %q, want %q", state, "state 1")
+	}
+
+	if err := newLeader.node.Propose([]byte("state 2")); err != nil {
+		t.Fatal(err)
+	}
+	follower := without(followers, newLeader)[0]
+	waitFor(t, 5*time.Second, "replication after the failover", func() bool {
+		state, leading := follower.state.get()
+		return state == "state 2" && !leading
+	})
+}
+
+func TestSingleReplicaLeadsAlone(t *testing.T) {
+	replicas := startReplicas(t, 1)
+	waitFor(t, 5*time.Second, "a leader", func() bool {
+		return replicas[0].node.IsLeader()
+	})
+	if err := replicas[0].node.Propose([]byte("state")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPartitionedLeaderStepsDown(t *testing.T) {
+	replicas := startReplicas(t, 3)
+
+	var leader *replica
+	waitFor(t, 5*time.Second, "a leader", func() bool {
+		leader = leaderOf(replicas)
+		return leader != nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := leader.node.Commit(ctx, []byte("committed")); err != nil {
+		t.Fatalf("Commit with a majority returned %v", err)
+	}
+
+	// Cut the leader off from both followers
+	for _, r := range without(replicas, leader) {
+		r.kill()
+	}
+	waitFor(t, 2*maxElectionTimeout, "the leader to step down", func() bool {
+		return !leader.node.IsLeader()
+	})
+	waitFor(t, time.Second, "the Follow callback", func() bool {
+		_, leading := leader.state.get()
+		return !leading
+	})
+	if err := leader.node.Commit(ctx, []byte("lost")); err != ErrNotLeader {
+		t.Errorf("Commit without a majority returned %v, want ErrNotLeader", err)
+	}
+}
+
+func TestRestartedReplicaDoesNotVoteTwiceInATerm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "raft.state")
+	peers := []string{"a", "b", "c"}
+	vote := func(node *Node, candidate string) bool {
+		resp, err := node.RequestVote(context.Background(), &api.VoteRequest{Term: 5, Candidate: candidate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Granted
+	}
+
+	node := NewNode("a", peers, &recorder{})
+	if err := node.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	if !vote(node, "b") {
+		t.Fatal("expected the first vote of the term to be granted")
+	}
+
+	restarted := NewNode("a", peers, &recorder{})
+	if err := restarted.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	if term := restarted.Term(); term != 5 {
+		t.Errorf("expected the term to survive the restart, got %d", term)
+	}
+	if vote(restarted, "c") {
+		t.Error("restarted replica voted for another candidate in the same term")
+	}
+	if !vote(restarted, "b") {
+		t.Error("expected the vote for the same candidate to be repeated")
+	}
+}
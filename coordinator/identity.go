@@ -0,0 +1,268 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	carTokenLifetime         = time.Hour        // cars register again once their token expired
+	coordinatorTokenLifetime = 30 * time.Second // tokens sent with routes and commands
+	operatorTokenLifetime    = 24 * time.Hour   // token written by -operatorToken
+)
+
+var (
+	issuer *security.Issuer
+
+	// Addresses the car ids were registered with and the other way round. A
+	// car id can only move to another address, and an address only be taken
+	// by another car id, once the old one went offline.
+	carAddresses     = make(map[string]string)
+	carIDs           = make(map[string]string)
+	carAddressMutex  sync.Mutex
+	coordinatorCalls = map[string]bool{
+		api.CoordinatorService_HandOver_FullMethodName:   true,
+		api.CoordinatorService_ShareRoute_FullMethodName: true,
+		api.ReplicaService_RequestVote_FullMethodName:    true,
+		api.ReplicaService_AppendEntries_FullMethodName:  true,
+	}
+	// operatorCalls change the fleet or its trips and need an operator token.
+	// Cars may request rides and command or recall themselves.
+	operatorCalls = map[string]bool{
+		api.CoordinatorService_SendCarCommand_FullMethodName: true,
+		api.CoordinatorService_RecallCar_FullMethodName:      true,
+		api.CoordinatorService_CancelTrip_FullMethodName:     true,
+		api.CoordinatorService_SetFleetState_FullMethodName:  true,
+		api.CoordinatorService_RequestRide_FullMethodName:    true,
+	}
+)
+
+// setupIdentity loads the key tokens are signed with. Coordinators which hand
+// cars to each other have to share it.
+func setupIdentity(path string) error {
+	key, err := security.LoadOrCreateKey(path)
+	if err != nil {
+		return err
+	}
+	issuer = security.NewIssuer(key)
+	return nil
+}
+
+// registerCar binds a car id to the address the car serves on and issues the
+// token the car proves its identity with.
+func registerCar(ctx context.Context, req *api.RegisterRequest) (*api.RegisterResponse, error) {
+	if req.CarId == "" || req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "car id and address are required")
+	}
+	// With mutual TLS the certificate decides who the car is
+	if name := security.PeerName(ctx); name != "" && name != req.CarId {
+		return nil, status.Errorf(codes.PermissionDenied, "certificate of %s cannot register %s", name, req.CarId)
+	}
+
+	carAddressMutex.Lock()
+	old := carAddresses[req.CarId]
+	if old != "" && old != req.Address && online(old) {
+		carAddressMutex.Unlock()
+		return nil, status.Errorf(codes.AlreadyExists, "%s is registered at %s", req.CarId, old)
+	}
+	holder := carIDs[req.Address]
+	if holder != "" && holder != req.CarId && online(req.Address) {
+		carAddressMutex.Unlock()
+		return nil, status.Errorf(codes.AlreadyExists, "%s is registered by %s", req.Address, holder)
+	}
+	// Without mutual TLS only the current holder of an online id may register
+	// it again, proven by a token of the id however old
+	if old != "" && online(old) && security.PeerName(ctx) == "" {
+		claims, err := security.Verify(issuer.PublicKey(), security.TokenFromContext(ctx))
+		if (err != nil && !errors.Is(err, security.ErrExpiredToken)) || claims.Role != security.RoleCar || claims.Subject != req.CarId {
+			carAddressMutex.Unlock()
+			return nil, status.Errorf(codes.PermissionDenied, "%s is online, registering it again needs its token", req.CarId)
+		}
+	}
+	if holder != "" && holder != req.CarId {
+		delete(carAddresses, holder)
+	}
+	if old != "" && old != req.Address {
+		delete(carIDs, old)
+	}
+	carAddresses[req.CarId] = req.Address
+	carIDs[req.Address] = req.CarId
+	carAddressMutex.Unlock()
+
+	if old != "" && old != req.Address {
+		removeCar(old)
+		requeueTrips(old)
+		logging.Component("identity").Info("Car moved", logging.CarKey, req.CarId, "from", old, "to", req.Address)
+	}
+
+	token, err := issuer.Issue(security.Claims{
+		Subject: req.CarId,
+		Role:    security.RoleCar,
+		Address: req.Address,
+		Expires: time.Now().Add(carTokenLifetime),
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.Component("identity").Info("Car registered", logging.CarKey, req.CarId, "address", req.Address)
+	return &api.RegisterResponse{Token: token, CoordinatorKey: issuer.PublicKey()}, nil
+}
+
+func online(address string) bool {
+	lastSeenMutex.Lock()
+	defer lastSeenMutex.Unlock()
+	return time.Since(lastSeen[address]) < onlineTimeout
+}
+
+// coordinatorToken returns a short-lived token identifying the coordinator to
+// other coordinators.
+func coordinatorToken() (string, error) {
+	return carCallToken("")
+}
+
+// carCallToken returns a short-lived coordinator token only the car at
+// address accepts, so a car cannot pass it on to command another one.
+func carCallToken(address string) (string, error) {
+	return issuer.Issue(security.Claims{
+		Subject: security.RoleCoordinator,
+		Role:    security.RoleCoordinator,
+		Address: address,
+		Expires: time.Now().Add(coordinatorTokenLifetime),
+	})
+}
+
+// writeOperatorToken writes a token for the operator calls to path.
+func writeOperatorToken(path string) error {
+	token, err := issuer.Issue(security.Claims{
+		Subject: security.RoleOperator,
+		Role:    security.RoleOperator,
+		Expires: time.Now().Add(operatorTokenLifetime),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(token+"\n"), 0o600)
+}
+
+// knownCar reports whether a car serves on address, either in the fleet
+// view or registered.
+func knownCar(address string) bool {
+	carinfoMutex.Lock()
+	_, ok := carsByID[address]
+	carinfoMutex.Unlock()
+	if ok {
+		return true
+	}
+	carAddressMutex.Lock()
+	defer carAddressMutex.Unlock()
+	return carIDs[address] != ""
+}
+
+// dialCar connects to a known car. Every call carries a coordinator token for
+// this car, as cars only accept routes and commands from the coordinator.
+// Unknown addresses are refused, so requests cannot make the coordinator
+// call arbitrary hosts.
+func dialCar(address string) (*grpc.ClientConn, error) {
+	if !knownCar(address) {
+		return nil, status.Errorf(codes.NotFound, "unknown car %s", address)
+	}
+	token := func() (string, error) { return carCallToken(address) }
+	return grpc.Dial(address, security.DialOption(), grpc.WithUnaryInterceptor(security.TokenInterceptor(token)))
+}
+
+// authorize lets a car only report its own CarInfo, other coordinators only
+// hand over cars, share routes and replicate, and operators change the fleet.
+func authorize(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	switch {
+	case info.FullMethod == api.CoordinatorService_SendCarInfo_FullMethodName:
+		carInfo := req.(*api.CarInfo)
+		claims, err := verify(ctx, security.RoleCar)
+		if err != nil {
+			return nil, err
+		}
+		if claims.Subject != carInfo.CarId || claims.Address != carInfo.Identifier {
+			return nil, status.Errorf(codes.PermissionDenied, "token of %s at %s cannot report %s at %s", claims.Subject, claims.Address, carInfo.CarId, carInfo.Identifier)
+		}
+		if err := bindAddress(claims); err != nil {
+			return nil, err
+		}
+	case coordinatorCalls[info.FullMethod]:
+		claims, err := verify(ctx, security.RoleCoordinator)
+		if err != nil {
+			return nil, err
+		}
+		// Tokens sent to a car are bound to it and must not let the car act
+		// as a coordinator
+		if claims.Address != "" {
+			return nil, status.Errorf(codes.PermissionDenied, "token for the car at %s cannot send %s", claims.Address, info.FullMethod)
+		}
+	case operatorCalls[info.FullMethod]:
+		if err := authorizeOperator(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// bindAddress checks that the id and address of a car token still belong to
+// each other. Tokens outlive restarts when the key is kept, so unknown ones
+// are bound again.
+func bindAddress(claims security.Claims) error {
+	carAddressMutex.Lock()
+	defer carAddressMutex.Unlock()
+	address, id := carAddresses[claims.Subject], carIDs[claims.Address]
+	if (address != "" && address != claims.Address) || (id != "" && id != claims.Subject) {
+		return status.Errorf(codes.PermissionDenied, "%s at %s was registered again", claims.Subject, claims.Address)
+	}
+	carAddresses[claims.Subject] = claims.Address
+	carIDs[claims.Address] = claims.Subject
+	return nil
+}
+
+// authorizeOperator lets operators make every fleet call and cars request
+// rides and command or recall only themselves.
+func authorizeOperator(ctx context.Context, method string, req any) error {
+	claims, err := security.Verify(issuer.PublicKey(), security.TokenFromContext(ctx))
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	switch claims.Role {
+	case security.RoleOperator:
+		return nil
+	case security.RoleCar:
+		switch req := req.(type) {
+		case *api.RideRequest:
+			return nil
+		case *api.CarCommand:
+			if req.Identifier == claims.Address {
+				return nil
+			}
+		case *api.CarRequest:
+			if req.Identifier == claims.Address {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "%s %s cannot send %s", claims.Role, claims.Subject, method)
+}
+
+func verify(ctx context.Context, role string) (security.Claims, error) {
+	claims, err := security.Verify(issuer.PublicKey(), security.TokenFromContext(ctx))
+	if err != nil {
+		return claims, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if claims.Role != role {
+		return claims, status.Errorf(codes.PermissionDenied, "%s token where a %s token is required", claims.Role, role)
+	}
+	return claims, nil
+}
@@ -0,0 +1,166 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// setupRegistry starts with a new signing key and no registered cars.
+func setupRegistry(t *testing.T) {
+	resetFleetView()
+	if err := setupIdentity(""); err != nil {
+		t.Fatal(err)
+	}
+	carAddressMutex.Lock()
+	carAddresses = make(map[string]string)
+	carIDs = make(map[string]string)
+	carAddressMutex.Unlock()
+}
+
+func issue(t *testing.T, claims security.Claims) string {
+	t.Helper()
+	if claims.Expires.IsZero() {
+		claims.Expires = time.Now().Add(time.Minute)
+	}
+	token, err := issuer.Issue(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// incoming returns the context of a call carrying token.
+func incoming(token string) context.Context {
+	if token == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// call runs authorize for method and reports the status code.
+func call(token, method string, req any) codes.Code {
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	_, err := authorize(incoming(token), req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return status.Code(err)
+}
+
+func TestOperatorCallsNeedOperatorOrOwnCarToken(t *testing.T) {
+	setupRegistry(t)
+	operator := issue(t, security.Claims{Subject: security.RoleOperator, Role: security.RoleOperator})
+	car := issue(t, security.Claims{Subject: "car-a", Role: security.RoleCar, Address: "localhost:50001"})
+	coordinator := issue(t, security.Claims{Subject: security.RoleCoordinator, Role: security.RoleCoordinator})
+
+	own := &api.CarCommand{Identifier: "localhost:50001", Command: &api.Command{Type: api.CommandType_PAUSE}}
+	other := &api.CarCommand{Identifier: "localhost:50002", Command: &api.Command{Type: api.CommandType_PAUSE}}
+	for _, c := range []struct {
+		name   string
+		token  string
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{"fleet state without token", "", api.CoordinatorService_SetFleetState_FullMethodName, &api.FleetStateRequest{}, codes.Unauthenticated},
+		{"fleet state by operator", operator, api.CoordinatorService_SetFleetState_FullMethodName, &api.FleetStateRequest{}, codes.OK},
+		{"fleet state by car", car, api.CoordinatorService_SetFleetState_FullMethodName, &api.FleetStateRequest{}, codes.PermissionDenied},
+		{"fleet state by coordinator", coordinator, api.CoordinatorService_SetFleetState_FullMethodName, &api.FleetStateRequest{}, codes.PermissionDenied},
+		{"command to another car by operator", operator, api.CoordinatorService_SendCarCommand_FullMethodName, other, codes.OK},
+		{"command to itself by car", car, api.CoordinatorService_SendCarCommand_FullMethodName, own, codes.OK},
+		{"command to another car by car", car, api.CoordinatorService_SendCarCommand_FullMethodName, other, codes.PermissionDenied},
+		{"recall of another car by car", car, api.CoordinatorService_RecallCar_FullMethodName, &api.CarRequest{Identifier: "localhost:50002"}, codes.PermissionDenied},
+		{"cancellation by car", car, api.CoordinatorService_CancelTrip_FullMethodName, &api.CancelTripRequest{TripId: "trip-1"}, codes.PermissionDenied},
+		{"ride without token", "", api.CoordinatorService_RequestRide_FullMethodName, &api.RideRequest{}, codes.Unauthenticated},
+		{"ride by car", car, api.CoordinatorService_RequestRide_FullMethodName, &api.RideRequest{}, codes.OK},
+		{"nearby cars without token", "", api.CoordinatorService_GetNearbyCars_FullMethodName, &api.NearbyRequest{}, codes.OK},
+	} {
+		if got := call(c.token, c.method, c.req); got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDialCarRefusesUnknownAddresses(t *testing.T) {
+	car := setupFleet(t)
+	if _, err := dialCar("example.com:443"); status.Code(err) != codes.NotFound {
+		t.Errorf("expected an unknown address to be refused, got %v", err)
+	}
+	conn, err := dialCar(car.address)
+	if err != nil {
+		t.Fatalf("expected a car of the fleet view to be dialed, got %v", err)
+	}
+	conn.Close()
+}
+
+func TestRegistrationKeepsIDsAndAddressesApart(t *testing.T) {
+	setupRegistry(t)
+	register := func(token, id, address string) codes.Code {
+		_, err := registerCar(incoming(token), &api.RegisterRequest{CarId: id, Address: address})
+		return status.Code(err)
+	}
+	report := func(token, id, address string) codes.Code {
+		return call(token, api.CoordinatorService_SendCarInfo_FullMethodName, &api.CarInfo{CarId: id, Identifier: address})
+	}
+
+	if got := register("", "car-a", "localhost:50001"); got != codes.OK {
+		t.Fatalf("first registration: got %v", got)
+	}
+	carSeen(nil, &api.CarInfo{Identifier: "localhost:50001", Position: &api.Coordinate{X: 1, Y: 1}})
+
+	// Another car may not take the address of an online one
+	if got := register("", "car-b", "localhost:50001"); got != codes.AlreadyExists {
+		t.Errorf("registration of another id at the address: got %v, want AlreadyExists", got)
+	}
+	forged := issue(t, security.Claims{Subject: "car-b", Role: security.RoleCar, Address: "localhost:50001"})
+	if got := report(forged, "car-b", "localhost:50001"); got != codes.PermissionDenied {
+		t.Errorf("report of another id at the address: got %v, want PermissionDenied", got)
+	}
+
+	// An online id is only registered again with its token, even an expired one
+	if got := register("", "car-a", "localhost:50001"); got != codes.PermissionDenied {
+		t.Errorf("registration of an online id without token: got %v, want PermissionDenied", got)
+	}
+	expired := issue(t, security.Claims{Subject: "car-a", Role: security.RoleCar, Address: "localhost:50001", Expires: time.Now().Add(-time.Minute)})
+	if got := register(expired, "car-a", "localhost:50001"); got != codes.OK {
+		t.Errorf("registration of an online id with its expired token: got %v", got)
+	}
+	valid := issue(t, security.Claims{Subject: "car-a", Role: security.RoleCar, Address: "localhost:50001"})
+	if got := report(valid, "car-a", "localhost:50001"); got != codes.OK {
+		t.Errorf("report of the registered car: got %v", got)
+	}
+}
+
+func TestCarBoundTokensCannotActAsCoordinator(t *testing.T) {
+	setupRegistry(t)
+	bound, err := carCallToken("localhost:50001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinator, err := coordinatorToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name   string
+		token  string
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{"handover with a car-bound token", bound, api.CoordinatorService_HandOver_FullMethodName, &api.Handover{}, codes.PermissionDenied},
+		{"replication with a car-bound token", bound, api.ReplicaService_AppendEntries_FullMethodName, &api.AppendRequest{}, codes.PermissionDenied},
+		{"vote with a car-bound token", bound, api.ReplicaService_RequestVote_FullMethodName, &api.VoteRequest{}, codes.PermissionDenied},
+		{"handover by coordinator", coordinator, api.CoordinatorService_HandOver_FullMethodName, &api.Handover{}, codes.OK},
+		{"replication by coordinator", coordinator, api.ReplicaService_AppendEntries_FullMethodName, &api.AppendRequest{}, codes.OK},
+	} {
+		if got := call(c.token, c.method, c.req); got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const onlineTimeout = 5 * time.Second // cars without update for this long count as offline
+
+var (
+	lastSeen      = make(map[string]time.Time)
+	lastSeenMutex sync.Mutex
+
+	carInfoUpdates = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fleet_car_info_updates_total",
+		Help: "CarInfo updates received via SendCarInfo.",
+	})
+	handovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fleet_car_handovers_total",
+		Help: "Cars handed over to the coordinator of another region.",
+	})
+	collisions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fleet_collisions_total",
+		Help: "Cars entering a cell occupied by another car.",
+	})
+	dispatchLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "fleet_dispatch_latency_seconds",
+		Help:    "Time from a trip request until a car accepted it.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+	})
+	tripDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "fleet_trip_duration_seconds",
+		Help:    "Time from a trip request until its passengers were dropped off.",
+		Buckets: prometheus.LinearBuckets(10, 10, 12),
+	})
+	etaDeviation = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fleet_eta_error_seconds",
+		Help:    "Actual minus promised arrival time, positive if late.",
+		Buckets: []float64{-30, -10, -5, -2, -1, 0, 1, 2, 5, 10, 30},
+	}, []string{"stop"})
+)
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fleet_cars_online",
+		Help: "Cars which reported within the last seconds.",
+	}, func() float64 { return countCars(func(*api.CarInfo) bool { return true }) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fleet_cars_busy",
+		Help: "Online cars with an active itinerary.",
+	}, func() float64 { return countCars(func(c *api.CarInfo) bool { return c.ActiveRoute }) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fleet_cars_idle",
+		Help: "Online cars without an itinerary.",
+	}, func() float64 { return countCars(func(c *api.CarInfo) bool { return !c.ActiveRoute }) })
+	for value, name := range api.CarState_name {
+		state := api.CarState(value)
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "fleet_cars_by_state",
+			Help:        "Online cars per reported state.",
+			ConstLabels: prometheus.Labels{"state": name},
+		}, func() float64 { return countCars(func(c *api.CarInfo) bool { return c.State == state }) })
+	}
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fleet_trips_pending",
+		Help: "Trips waiting for a car.",
+	}, pendingTrips)
+}
+
+// carSeen records an update of a car and counts collisions.
+func carSeen(oldCarInfo, carInfo *api.CarInfo) {
+	carInfoUpdates.Inc()
+
+	lastSeenMutex.Lock()
+	lastSeen[carInfo.Identifier] = time.Now()
+	lastSeenMutex.Unlock()
+
+	if oldCarInfo != nil && oldCarInfo.Position.X == carInfo.Position.X && oldCarInfo.Position.Y == carInfo.Position.Y {
+		return // Did not enter a new cell
+	}
+	for _, id := range carIndex.Query(carInfo.Position, 0) {
+		if id != carInfo.Identifier {
+			collisions.Inc()
+			events.record(event{Type: eventCollision, Car: carInfo.Identifier, Other: id, Position: carInfo.Position})
+			logging.Component("fleet").Warn("Collision", logging.CarKey, carInfo.Identifier, "other", id, "position", carInfo.Position)
+		}
+	}
+}
+
+// onlineCars returns the cars which reported within onlineTimeout.
+func onlineCars() map[string]bool {
+	lastSeenMutex.Lock()
+	defer lastSeenMutex.Unlock()
+
+	online := make(map[string]bool, len(lastSeen))
+	for identifier, seen := range lastSeen {
+		if time.Since(seen) < onlineTimeout {
+			online[identifier] = true
+		}
+	}
+	return online
+}
+
+// countCars counts the online cars matching filter.
+func countCars(filter func(*api.CarInfo) bool) float64 {
+	lastSeenMutex.Lock()
+	defer lastSeenMutex.Unlock()
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+
+	count := 0
+	for _, carInfo := range carinfos {
+		if time.Since(lastSeen[carInfo.Identifier]) < onlineTimeout && filter(carInfo) {
+			count++
+		}
+	}
+	return float64(count)
+}
+
+func pendingTrips() float64 {
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+
+	count := 0
+	for _, t := range trips {
+		if t.car == "" && !t.cancelled {
+			count++
+		}
+	}
+	return float64(count)
+}
+
+// startMetricsServer serves the Prometheus metrics on address under /metrics.
+func startMetricsServer(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	logging.Component("metrics").Info("Serving metrics", "address", address+"/metrics")
+	if err := http.ListenAndServe(address, mux); err != nil {
+		logging.Component("metrics").Error("Failed to serve metrics", "err", err)
+	}
+}
@@ -0,0 +1,37 @@
+package coordinator
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// observations returns the number of samples of a histogram.
+func observations(t *testing.T, histogram prometheus.Histogram) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := histogram.(prometheus.Metric).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestServedTripsAreMeasured(t *testing.T) {
+	car := setupFleet(t)
+	durations := observations(t, tripDuration)
+	pickups := observations(t, etaDeviation.WithLabelValues("pickup").(prometheus.Histogram))
+	dropoffs := observations(t, etaDeviation.WithLabelValues("dropoff").(prometheus.Histogram))
+
+	serveTrip(t, car)
+
+	if got := observations(t, tripDuration) - durations; got != 1 {
+		t.Errorf("expected one trip duration, got %d", got)
+	}
+	if got := observations(t, etaDeviation.WithLabelValues("pickup").(prometheus.Histogram)) - pickups; got != 1 {
+		t.Errorf("expected one pickup ETA error, got %d", got)
+	}
+	if got := observations(t, etaDeviation.WithLabelValues("dropoff").(prometheus.Histogram)) - dropoffs; got != 1 {
+		t.Errorf("expected one dropoff ETA error, got %d", got)
+	}
+}
@@ -0,0 +1,261 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"gioui.org/app"
+	gioevent "gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const replayTick = 50 * time.Millisecond
+
+// replay plays back a recorded event log on the grid without any cars
+// connected. Seeking backwards rebuilds the grid from the first event.
+type replay struct {
+	mu      sync.Mutex
+	events  []event
+	next    int           // index of the next event to apply
+	clock   time.Duration // playback position since the first event
+	playing bool
+	speed   float64
+	window  *app.Window
+}
+
+func loadReplay(path string) (*replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &replay{playing: true, speed: 1}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			logging.Component("replay").Warn("Skipping malformed event", "line", line, "err", err)
+			continue
+		}
+		r.events = append(r.events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(r.events) == 0 {
+		return nil, fmt.Errorf("event log %s is empty", path)
+	}
+	return r, nil
+}
+
+// offset returns the time of event i since the first event.
+func (r *replay) offset(i int) time.Duration {
+	return r.events[i].Time.Sub(r.events[0].Time)
+}
+
+func (r *replay) duration() time.Duration {
+	return r.offset(len(r.events) - 1)
+}
+
+// apply replays a single event on the fleet view, like waitForUpdates does for live updates.
+func (r *replay) apply(e event) {
+	switch e.Type {
+	case eventCarRegistered, eventPositionUpdate:
+		carInfo := &api.CarInfo{}
+		if err := protojson.Unmarshal(e.CarInfo, carInfo); err != nil || carInfo.Position == nil {
+			return
+		}
+		oldCarInfo := updateCarinfo(carInfo)
+		carIndex.Update(carInfo.Identifier, carInfo.Position)
+		updateGridData(oldCarInfo, carInfo)
+	case eventTripGenerated:
+		t := &trip{
+			id:           e.Trip,
+			pickup:       e.Pickup,
+			dropoff:      e.Dropoff,
+			passengers:   e.Passengers,
+			requirements: e.Requirements,
+			route:        &api.Route{Coordinates: e.Route},
+		}
+		registerTrip(t)
+		carinfoMutex.Lock()
+		updateGridDataRoute(t.route, "")
+		carinfoMutex.Unlock()
+	case eventTripAssigned:
+		tripMutex.Lock()
+		t, ok := trips[e.Trip]
+		if ok {
+			t.car = e.Car
+		}
+		tripMutex.Unlock()
+		if ok {
+			carinfoMutex.Lock()
+			updateGridDataRoute(t.route, carsByID[e.Car].GetColor())
+			carinfoMutex.Unlock()
+		}
+	case eventTripCancelled, eventTripAborted:
+		clearTripRoute(e.Trip)
+	case eventCarHandedOver:
+		removeCar(e.Car)
+	case eventFleetState:
+		fleetMutex.Lock()
+		fleetState = api.FleetState(api.FleetState_value[e.State])
+		fleetMutex.Unlock()
+	}
+}
+
+// reset empties the fleet view before replaying from the first event.
+func (r *replay) reset() {
+	resetFleetView()
+	r.next = 0
+}
+
+// seekIndex replays up to, but excluding, event i. Must be called with r.mu held.
+func (r *replay) seekIndex(i int) {
+	i = max(0, min(i, len(r.events)))
+	if i < r.next {
+		r.reset()
+	}
+	for ; r.next < i; r.next++ {
+		r.apply(r.events[r.next])
+	}
+}
+
+// seek moves the playback to clock. Must be called with r.mu held.
+func (r *replay) seek(clock time.Duration) {
+	clock = max(0, min(clock, r.duration()))
+	i := 0
+	for i < len(r.events) && r.offset(i) <= clock {
+		i++
+	}
+	r.seekIndex(i)
+	r.clock = clock
+}
+
+// step applies the next event or takes back the last one. Must be called with r.mu held.
+func (r *replay) step(delta int) {
+	r.playing = false
+	r.seekIndex(r.next + delta)
+	r.clock = 0
+	if r.next > 0 {
+		r.clock = r.offset(r.next - 1)
+	}
+}
+
+func (r *replay) title() string {
+	status := "playing"
+	if !r.playing {
+		status = "paused"
+	}
+	return fmt.Sprintf("Replay %.1fs / %.1fs, event %d / %d, x%g, %s",
+		r.clock.Seconds(), r.duration().Seconds(), r.next, len(r.events), r.speed, status)
+}
+
+// run advances the playback in real time scaled by the speed.
+func (r *replay) run() {
+	ticker := time.NewTicker(replayTick)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.mu.Lock()
+		if r.playing {
+			r.seek(r.clock + time.Duration(float64(replayTick)*r.speed))
+			if r.next == len(r.events) {
+				r.playing = false
+			}
+		}
+		title := r.title()
+		r.mu.Unlock()
+
+		r.window.Option(app.Title(title))
+		r.window.Invalidate()
+	}
+}
+
+// runReplay shows a recorded run in the coordinator window.
+func runReplay(path string) {
+	r, err := loadReplay(path)
+	if err != nil {
+		logging.Component("replay").Error("Failed to load event log", "path", path, "err", err)
+		os.Exit(1)
+	}
+	logging.Component("replay").Info("Replaying", "path", path, "events", len(r.events), "duration", r.duration())
+
+	r.window = new(app.Window)
+	go display(r.window, r.handleShortcuts)
+	go r.run()
+	app.Main()
+}
+
+// handleShortcuts controls the playback: Space plays and pauses, the arrow
+// keys step through single events, + and - change the speed, Home and End
+// jump to the start and end and the digits seek to tenths of the run.
+func (r *replay) handleShortcuts(gtx layout.Context, window *app.Window) {
+	filters := []key.Filter{
+		{Name: key.NameSpace},
+		{Name: key.NameLeftArrow},
+		{Name: key.NameRightArrow},
+		{Name: key.NameHome},
+		{Name: key.NameEnd},
+		{Name: "+"},
+		{Name: "="},
+		{Name: "-"},
+	}
+	for digit := '0'; digit <= '9'; digit++ {
+		filters = append(filters, key.Filter{Name: key.Name(string(digit))})
+	}
+	watched := make([]gioevent.Filter, len(filters))
+	for i := range filters {
+		watched[i] = filters[i]
+	}
+
+	for {
+		ev, ok := gtx.Event(watched...)
+		if !ok {
+			return
+		}
+		e, ok := ev.(key.Event)
+		if !ok || e.State != key.Press {
+			continue
+		}
+
+		r.mu.Lock()
+		switch e.Name {
+		case key.NameSpace:
+			r.playing = !r.playing
+			if r.playing && r.next == len(r.events) {
+				r.seek(0) // Play again from the start
+			}
+		case key.NameRightArrow:
+			r.step(1)
+		case key.NameLeftArrow:
+			r.step(-1)
+		case key.NameHome:
+			r.seek(0)
+		case key.NameEnd:
+			r.seek(r.duration())
+		case "+", "=":
+			r.speed = min(r.speed*2, 64)
+		case "-":
+			r.speed = max(r.speed/2, 0.125)
+		default:
+			tenth := time.Duration(e.Name[0] - '0')
+			r.seek(r.duration() * tenth / 10)
+		}
+		title := r.title()
+		r.mu.Unlock()
+
+		window.Option(app.Title(title))
+		window.Invalidate()
+	}
+}
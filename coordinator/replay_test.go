@@ -0,0 +1,120 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeEventLog writes the events one second apart as a JSONL log with a
+// malformed line in between.
+func writeEventLog(t *testing.T, events ...event) string {
+	t.Helper()
+	start := time.Now()
+	var lines []string
+	for i, e := range events {
+		e.Seq = uint64(i + 1)
+		e.Time = start.Add(time.Duration(i) * time.Second)
+		line, err := json.Marshal(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(line))
+		if i == 0 {
+			lines = append(lines, "{not json")
+		}
+	}
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func carAt(identifier string, x, y int32) *api.CarInfo {
+	return &api.CarInfo{Identifier: identifier, Position: &api.Coordinate{X: x, Y: y}}
+}
+
+// replayedCar returns the position of identifier in the fleet view.
+func replayedCar(identifier string) *api.Coordinate {
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+	return carsByID[identifier].GetPosition()
+}
+
+func replayedTrip(id string) (string, bool) {
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+	t, ok := trips[id]
+	if !ok {
+		return "", false
+	}
+	return t.car, true
+}
+
+func TestReplayStepsForwardAndBack(t *testing.T) {
+	resetFleetView()
+	t.Cleanup(resetFleetView)
+	const car = "localhost:50001"
+	path := writeEventLog(t,
+		carEvent(eventCarRegistered, carAt(car, 1, 1)),
+		carEvent(eventPositionUpdate, carAt(car, 1, 2)),
+		event{Type: eventTripGenerated, Trip: "trip-1", Pickup: &api.Coordinate{X: 1, Y: 3}, Dropoff: &api.Coordinate{X: 5, Y: 3}, Passengers: 1},
+		event{Type: eventTripAssigned, Trip: "trip-1", Car: car},
+		event{Type: eventFleetState, State: api.FleetState_PAUSED.String()},
+	)
+
+	r, err := loadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.events) != 5 || r.duration() != 4*time.Second {
+		t.Fatalf("expected 5 events over 4s without the malformed line, got %d over %v", len(r.events), r.duration())
+	}
+
+	r.step(1)
+	r.step(1)
+	if pos := replayedCar(car); pos.GetY() != 2 {
+		t.Errorf("after the position update: car at %v, want (1,2)", pos)
+	}
+	r.step(1)
+	r.step(1)
+	if assigned, ok := replayedTrip("trip-1"); !ok || assigned != car {
+		t.Errorf("after the assignment: trip known %v, assigned to %q", ok, assigned)
+	}
+	if r.clock != 3*time.Second {
+		t.Errorf("expected the clock at the last applied event, got %v", r.clock)
+	}
+
+	// Stepping back replays from the first event
+	r.step(-1)
+	if assigned, ok := replayedTrip("trip-1"); !ok || assigned != "" {
+		t.Errorf("after stepping back over the assignment: trip known %v, assigned to %q", ok, assigned)
+	}
+	r.step(-2)
+	if pos := replayedCar(car); pos.GetY() != 1 {
+		t.Errorf("after stepping back over the position update: car at %v, want (1,1)", pos)
+	}
+	if _, ok := replayedTrip("trip-1"); ok {
+		t.Error("trip is still known before it was generated")
+	}
+
+	r.seek(r.duration())
+	if r.next != len(r.events) || !fleetPaused() {
+		t.Errorf("expected all events applied and the fleet paused at the end, got event %d", r.next)
+	}
+	r.seek(0)
+	if r.next != 1 || fleetPaused() || replayedCar(car).GetY() != 1 {
+		t.Errorf("expected only the registration at the start, got event %d", r.next)
+	}
+}
+
+func fleetPaused() bool {
+	fleetMutex.Lock()
+	defer fleetMutex.Unlock()
+	return fleetState == api.FleetState_PAUSED
+}
@@ -0,0 +1,123 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/coordinator/ha"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
+	"context"
+	"strings"
+	"time"
+
+	"gioui.org/app"
+	"google.golang.org/grpc"
+)
+
+const commitTimeout = 2 * time.Second // how long a ride request waits for the replicas to store it
+
+var (
+	replication  *ha.Node // nil unless -replicas is given
+	stateChanges = make(chan struct{}, 1)
+)
+
+// leading reports whether this coordinator may change the fleet: always
+// without replication, otherwise only as leader. Followers only mirror the
+// state of the leader and redirect calls to it.
+func leading() bool {
+	return replication == nil || replication.IsLeader()
+}
+
+// replicatedState connects the coordinator state to the replication.
+type replicatedState struct {
+	window *app.Window
+}
+
+func (r replicatedState) Apply(data []byte) {
+	if _, err := loadSnapshot(data); err != nil {
+		logging.Component("ha").Warn("Failed to apply replicated state", "err", err)
+		return
+	}
+	r.window.Invalidate()
+}
+
+// Lead takes over the trips of the replicated state like after a restart.
+func (r replicatedState) Lead() {
+	pending := resumeTrips()
+	logging.Component("ha").Info("Leading the fleet", "pending", pending)
+	r.window.Option(app.Title("Coordinator (leader)"))
+}
+
+func (r replicatedState) Follow(leader string) {
+	logging.Component("ha").Info("Following", "leader", leader)
+	r.window.Option(app.Title("Coordinator (follower)"))
+}
+
+// stateChanged proposes the coordinator state right away after trips or the
+// fleet state changed. Changes arriving meanwhile are proposed together.
+func stateChanged() {
+	select {
+	case stateChanges <- struct{}{}:
+	default:
+	}
+}
+
+// commitState waits until a majority of the replicas stored the current
+// state, so an acknowledged change survives a failover.
+func commitState(ctx context.Context) error {
+	if replication == nil {
+		return nil
+	}
+	data, err := encodeSnapshot()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, commitTimeout)
+	defer cancel()
+	return replication.Commit(ctx, data)
+}
+
+// startReplication joins the replicas and proposes the coordinator state after
+// every change of the trips, and every interval for the car positions, while
+// leading. Term and vote are kept in the file at statePath.
+func startReplication(self string, replicas []string, statePath string, interval time.Duration, window *app.Window) error {
+	replication = ha.NewNode(self, replicas, replicatedState{window: window})
+	if err := replication.Persist(statePath); err != nil {
+		return err
+	}
+	if err := replication.Start(grpc.WithUnaryInterceptor(security.TokenInterceptor(coordinatorToken))); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-stateChanges:
+			}
+			if !replication.IsLeader() {
+				continue
+			}
+			data, err := encodeSnapshot()
+			if err != nil {
+				logging.Component("ha").Warn("Failed to encode state", "err", err)
+				continue
+			}
+			if err := replication.Propose(data); err != nil {
+				logging.Component("ha").Debug("Failed to propose state", "err", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func splitAddresses(list string) []string {
+	var addresses []string
+	for _, address := range strings.Split(list, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
@@ -2,21 +2,45 @@ package coordinator
 
 import (
 	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/coordinator/ha"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
 	"context"
-	"log"
 	"net"
+	"os"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type CoordinatorServiceServer struct {
 	api.CoordinatorServiceServer
 }
 
+func (s *CoordinatorServiceServer) Register(ctx context.Context, req *api.RegisterRequest) (*api.RegisterResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	return registerCar(ctx, req)
+}
+
 func (s *CoordinatorServiceServer) SendCarInfo(ctx context.Context, req *api.CarInfo) (*api.CarInfoResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	// Cars which entered another region move to its owner
+	if owner := shards.ownerOf(req.Position); owner != "" {
+		err := shards.handOver(req, owner)
+		if err == nil {
+			return nil, ha.RedirectTo(ctx, owner, "car left the region")
+		}
+		logging.Component("shards").Warn("Failed to hand over car, keeping it", logging.CarKey, req.Identifier, "owner", owner, "err", err)
+	}
+
 	// Send CarInfo to the channel
 	carInfoCh <- req
-	log.Printf("Car info received successfully from: %v", req.Identifier)
+	logging.Component("server").Debug("Car info received", logging.CarKey, req.Identifier)
 
 	// Return success message
 	return &api.CarInfoResponse{
@@ -24,21 +48,113 @@ func (s *CoordinatorServiceServer) SendCarInfo(ctx context.Context, req *api.Car
 	}, nil
 }
 
-func startServer() {
+func (s *CoordinatorServiceServer) GetNearbyCars(ctx context.Context, req *api.NearbyRequest) (*api.NearbyResponse, error) {
+	return &api.NearbyResponse{
+		Cars: shards.nearbyCars(req),
+	}, nil
+}
+
+func (s *CoordinatorServiceServer) SendCarCommand(ctx context.Context, req *api.CarCommand) (*api.CommandResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	if owner := shards.carOwner(req.Identifier); owner != "" {
+		return nil, ha.RedirectTo(ctx, owner, "car moved to another region")
+	}
+	return sendCommand(req.Identifier, req.Command)
+}
+
+func (s *CoordinatorServiceServer) CancelTrip(ctx context.Context, req *api.CancelTripRequest) (*api.CommandResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	if owner := shards.tripOwner(req.TripId); owner != "" {
+		return nil, ha.RedirectTo(ctx, owner, "trip moved to another region")
+	}
+	if err := cancelTrip(req.TripId); err != nil {
+		return nil, err
+	}
+	return &api.CommandResponse{Message: "Trip " + req.TripId + " cancelled"}, nil
+}
+
+func (s *CoordinatorServiceServer) RecallCar(ctx context.Context, req *api.CarRequest) (*api.CommandResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	if owner := shards.carOwner(req.Identifier); owner != "" {
+		return nil, ha.RedirectTo(ctx, owner, "car moved to another region")
+	}
+	if err := recallCar(req.Identifier); err != nil {
+		return nil, err
+	}
+	return &api.CommandResponse{Message: req.Identifier + " recalled"}, nil
+}
+
+func (s *CoordinatorServiceServer) SetFleetState(ctx context.Context, req *api.FleetStateRequest) (*api.CommandResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	setFleetState(req.State)
+	return &api.CommandResponse{Message: "Fleet is now " + req.State.String()}, nil
+}
+
+func (s *CoordinatorServiceServer) RequestRide(ctx context.Context, req *api.RideRequest) (*api.RideResponse, error) {
+	if !leading() {
+		return nil, replication.Redirect(ctx)
+	}
+	if owner := shards.ownerOf(req.Pickup); owner != "" {
+		return nil, ha.RedirectTo(ctx, owner, "pickup is in another region")
+	}
+	response, err := requestRide(ctx, req.Pickup, req.Dropoff, req.Passengers, req.Requirements)
+	if err != nil {
+		return nil, err
+	}
+	// Only acknowledge trips the replicas know about, a failover keeps them
+	if err := commitState(ctx); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "trip %s not replicated: %v", response.TripId, err)
+	}
+	return response, nil
+}
+
+func (s *CoordinatorServiceServer) GetTripEta(ctx context.Context, req *api.TripRequest) (*api.RideResponse, error) {
+	if owner := shards.tripOwner(req.TripId); owner != "" {
+		return nil, ha.RedirectTo(ctx, owner, "trip moved to another region")
+	}
+	return tripETA(req.TripId)
+}
+
+func (s *CoordinatorServiceServer) HandOver(ctx context.Context, req *api.Handover) (*api.CommandResponse, error) {
+	if err := shards.takeOver(req); err != nil {
+		return nil, err
+	}
+	return &api.CommandResponse{Message: req.CarInfo.Identifier + " taken over"}, nil
+}
+
+func (s *CoordinatorServiceServer) ShareRoute(ctx context.Context, req *api.SharedRoute) (*api.CommandResponse, error) {
+	receiveRoute(req)
+	return &api.CommandResponse{Message: "Route of " + req.TripId + " received"}, nil
+}
+
+func startServer(address string) {
 	// Create a gRPC server
-	server := grpc.NewServer()
+	server := grpc.NewServer(security.ServerOption(), grpc.UnaryInterceptor(authorize))
 
 	// Register your server implementation
 	coordinatorServer := &CoordinatorServiceServer{}
 	api.RegisterCoordinatorServiceServer(server, coordinatorServer)
+	if replication != nil {
+		replication.Register(server)
+	}
 
 	// Start the server on a specific port
-	listener, err := net.Listen("tcp", ":50000")
+	listener, err := net.Listen("tcp", address)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logging.Component("server").Error("Failed to listen", "err", err)
+		os.Exit(1)
 	}
-	log.Println("Server started")
+	logging.Component("server").Info("Server started", "address", listener.Addr().String())
 	if err := server.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+		logging.Component("server").Error("Failed to serve", "err", err)
+		os.Exit(1)
 	}
 }
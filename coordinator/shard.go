@@ -0,0 +1,306 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/security"
+	"AutonomousCarFleetSimulation/utils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const shardTimeout = 2 * time.Second // timeout of calls between the region owners
+
+var shards *sharding // nil unless -shards is given
+
+// sharding partitions the grid into regions, each owned by one coordinator.
+// A car reports to the owner of the region it is in and is handed over
+// together with its open trips when it crosses a region boundary.
+type sharding struct {
+	region     int          // index of the region owned by this coordinator
+	owners     []string     // coordinator address per region
+	areas      []utils.Area // cells per region
+	mu         sync.Mutex
+	clients    map[string]api.CoordinatorServiceClient
+	gone       map[string]string // cars handed over to another region, to the new owner
+	movedTrips map[string]string // trips handed over with their car, to the new owner
+}
+
+func newSharding(region int, owners []string) (*sharding, error) {
+	if region < 0 || region >= len(owners) {
+		return nil, fmt.Errorf("region %d does not exist, there are %d", region, len(owners))
+	}
+	return &sharding{
+		region:     region,
+		owners:     owners,
+		areas:      utils.Regions(len(owners)),
+		clients:    make(map[string]api.CoordinatorServiceClient),
+		gone:       make(map[string]string),
+		movedTrips: make(map[string]string),
+	}, nil
+}
+
+// area returns the cells owned by this coordinator, the whole grid if the map
+// is not sharded.
+func (s *sharding) area() utils.Area {
+	if s == nil {
+		size := int32(utils.Settings.GridSize)
+		return utils.Area{Min: &api.Coordinate{X: 0, Y: 0}, Max: &api.Coordinate{X: size - 1, Y: size - 1}}
+	}
+	return s.areas[s.region]
+}
+
+// ownerOf returns the coordinator owning pos, or an empty string if it is
+// this one or the map is not sharded.
+func (s *sharding) ownerOf(pos *api.Coordinate) string {
+	if s == nil || pos == nil {
+		return ""
+	}
+	region := utils.RegionOf(pos, s.areas)
+	if region < 0 || region == s.region {
+		return ""
+	}
+	return s.owners[region]
+}
+
+// tripOwner returns the coordinator a trip was handed over to, if any.
+func (s *sharding) tripOwner(id string) string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.movedTrips[id]
+}
+
+// handedOver reports whether the car was handed over to another region.
+// Updates which were in flight during the handover are dropped.
+func (s *sharding) handedOver(identifier string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gone[identifier] != ""
+}
+
+// carOwner returns the coordinator a car was handed over to, if any.
+func (s *sharding) carOwner(identifier string) string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gone[identifier]
+}
+
+// nearbyCars adds the cars of the other regions within radius to the own,
+// so queries near a region border see across it. Unreachable owners are
+// skipped.
+func (s *sharding) nearbyCars(req *api.NearbyRequest) []*api.CarInfo {
+	cars := nearbyCars(req.Identifier, req.Position, int(req.Radius))
+	if s == nil || req.Forwarded || req.Position == nil {
+		return cars
+	}
+
+	forwarded := &api.NearbyRequest{Identifier: req.Identifier, Position: req.Position, Radius: req.Radius, Forwarded: true}
+	for _, region := range utils.RegionsWithin(req.Position, int(req.Radius), s.areas) {
+		if region == s.region {
+			continue
+		}
+		owner := s.owners[region]
+		client, err := s.client(owner)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), shardTimeout)
+			var resp *api.NearbyResponse
+			resp, err = client.GetNearbyCars(ctx, forwarded)
+			cancel()
+			if err == nil {
+				cars = append(cars, resp.Cars...)
+			}
+		}
+		if err != nil {
+			logging.Component("shards").Warn("Failed to query nearby cars", "owner", owner, "err", err)
+		}
+	}
+	return cars
+}
+
+func (s *sharding) client(address string) (api.CoordinatorServiceClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client, ok := s.clients[address]; ok {
+		return client, nil
+	}
+	conn, err := grpc.Dial(address, security.DialOption(), grpc.WithUnaryInterceptor(security.TokenInterceptor(coordinatorToken)))
+	if err != nil {
+		return nil, err
+	}
+	client := api.NewCoordinatorServiceClient(conn)
+	s.clients[address] = client
+	return client, nil
+}
+
+// handOver passes the car and its open trips to the owner of the region the
+// car entered. The car stays here if the owner cannot be reached or an
+// itinerary is still on its way to the car.
+func (s *sharding) handOver(carInfo *api.CarInfo, owner string) error {
+	// Keep dispatchers away from the car until it is gone
+	carinfoMutex.Lock()
+	if dispatching[carInfo.Identifier] {
+		carinfoMutex.Unlock()
+		return fmt.Errorf("itinerary is being sent to the car")
+	}
+	dispatching[carInfo.Identifier] = true
+	carinfoMutex.Unlock()
+	defer release(carInfo.Identifier)
+
+	tripMutex.Lock()
+	var moving []tripSnapshot
+	for _, t := range trips {
+		if t.car == carInfo.Identifier && !t.cancelled && t.droppedOff.IsZero() {
+			moving = append(moving, snapshotTrip(t))
+		}
+	}
+	tripMutex.Unlock()
+
+	data, err := json.Marshal(moving)
+	if err != nil {
+		return err
+	}
+	client, err := s.client(owner)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shardTimeout)
+	defer cancel()
+	if _, err := client.HandOver(ctx, &api.Handover{CarInfo: carInfo, Trips: data, From: s.owners[s.region]}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.gone[carInfo.Identifier] = owner
+	for _, ts := range moving {
+		s.movedTrips[ts.ID] = owner
+	}
+	s.mu.Unlock()
+
+	tripMutex.Lock()
+	for _, ts := range moving {
+		delete(trips, ts.ID)
+	}
+	tripMutex.Unlock()
+
+	removeCar(carInfo.Identifier)
+	handovers.Inc()
+	events.record(event{Type: eventCarHandedOver, Car: carInfo.Identifier, Other: owner})
+	logging.Component("shards").Info("Handed over car", logging.CarKey, carInfo.Identifier, "owner", owner, "trips", len(moving))
+	return nil
+}
+
+// takeOver accepts a car and its open trips from the owner of another region.
+func (s *sharding) takeOver(h *api.Handover) error {
+	if s == nil {
+		return fmt.Errorf("coordinator is not sharded")
+	}
+	var moving []tripSnapshot
+	if len(h.Trips) > 0 {
+		if err := json.Unmarshal(h.Trips, &moving); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.gone, h.CarInfo.Identifier)
+	for _, ts := range moving {
+		delete(s.movedTrips, ts.ID)
+	}
+	s.mu.Unlock()
+
+	for _, ts := range moving {
+		t := restoreTrip(ts)
+		registerTrip(t)
+		carinfoMutex.Lock()
+		updateGridDataRoute(t.route, h.CarInfo.Color)
+		carinfoMutex.Unlock()
+	}
+	carInfoCh <- h.CarInfo
+	logging.Component("shards").Info("Took over car", logging.CarKey, h.CarInfo.Identifier, "from", h.From, "trips", len(moving))
+	return nil
+}
+
+// shareRoute tells the owners of the regions a route crosses about it, so
+// they show it before the car arrives. Cleared routes are sent to all owners,
+// as any of them may show it.
+func (s *sharding) shareRoute(id string, route *api.Route, color string, cleared bool) {
+	if s == nil {
+		return
+	}
+
+	var targets []string
+	if cleared {
+		for region, owner := range s.owners {
+			if region != s.region {
+				targets = append(targets, owner)
+			}
+		}
+	} else {
+		for _, region := range utils.RegionsOnPath(route.Coordinates, s.areas) {
+			if region != s.region {
+				targets = append(targets, s.owners[region])
+			}
+		}
+	}
+
+	shared := &api.SharedRoute{TripId: id, Route: route, Color: color, Cleared: cleared}
+	for _, owner := range targets {
+		go func(owner string) {
+			client, err := s.client(owner)
+			if err == nil {
+				ctx, cancel := context.WithTimeout(context.Background(), shardTimeout)
+				defer cancel()
+				_, err = client.ShareRoute(ctx, shared)
+			}
+			if err != nil {
+				logging.Component("shards").Warn("Failed to share route", logging.TripKey, id, "owner", owner, "err", err)
+			}
+		}(owner)
+	}
+}
+
+// receiveRoute shows or clears a route shared by another region owner.
+func receiveRoute(shared *api.SharedRoute) {
+	if shared.Route == nil {
+		return
+	}
+	if shared.Cleared {
+		clearRoute(shared.Route)
+		return
+	}
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+	updateGridDataRoute(shared.Route, shared.Color)
+}
+
+// removeCar drops a car from the fleet view.
+func removeCar(identifier string) {
+	carinfoMutex.Lock()
+	for i, car := range carinfos {
+		if car.Identifier == identifier {
+			if utils.IsVehicle(gridData[car.Position.X][car.Position.Y][0]) {
+				gridData[car.Position.X][car.Position.Y] = utils.EmptyCell(car.Position.X, car.Position.Y)
+			}
+			carinfos = append(carinfos[:i], carinfos[i+1:]...)
+			delete(carsByID, identifier)
+			break
+		}
+	}
+	carinfoMutex.Unlock()
+	carIndex.Remove(identifier)
+}
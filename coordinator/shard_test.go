@@ -0,0 +1,150 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRegion is the owner of region 1, with one car next to the border.
+type fakeRegion struct {
+	api.CoordinatorServiceServer
+	address  string
+	mu       sync.Mutex
+	queries  []*api.NearbyRequest
+	received []string // cars handed over
+}
+
+func startFakeRegion(t *testing.T) *fakeRegion {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	region := &fakeRegion{address: listener.Addr().String()}
+	server := grpc.NewServer()
+	api.RegisterCoordinatorServiceServer(server, region)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return region
+}
+
+func (f *fakeRegion) GetNearbyCars(ctx context.Context, req *api.NearbyRequest) (*api.NearbyResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, req)
+	return &api.NearbyResponse{Cars: []*api.CarInfo{{Identifier: "remote", Position: &api.Coordinate{X: 8, Y: 5}}}}, nil
+}
+
+func (f *fakeRegion) HandOver(ctx context.Context, req *api.Handover) (*api.CommandResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.received = append(f.received, req.CarInfo.Identifier)
+	return &api.CommandResponse{Message: "Took over"}, nil
+}
+
+func (f *fakeRegion) queryCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.queries)
+}
+
+// setupShards makes this coordinator the owner of region 0, the left half of
+// the grid, next to the fake owner of region 1.
+func setupShards(t *testing.T) *fakeRegion {
+	region := startFakeRegion(t)
+	var err error
+	if shards, err = newSharding(0, []string{"localhost:0", region.address}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { shards = nil })
+	return region
+}
+
+func identifiers(cars []*api.CarInfo) []string {
+	var ids []string
+	for _, car := range cars {
+		ids = append(ids, car.Identifier)
+	}
+	return ids
+}
+
+func TestNearbyCarsAcrossRegionBorder(t *testing.T) {
+	setupFleet(t)
+	region := setupShards(t)
+	border := startFakeCar(t)
+	addFakeCar(border, &api.Coordinate{X: 7, Y: 5})
+	server := &CoordinatorServiceServer{}
+
+	resp, err := server.GetNearbyCars(context.Background(), &api.NearbyRequest{Position: &api.Coordinate{X: 7, Y: 4}, Radius: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := identifiers(resp.Cars); len(ids) != 2 || !contains(ids, border.address) || !contains(ids, "remote") {
+		t.Errorf("expected the car at the border and the one across it, got %v", ids)
+	}
+	if region.queryCount() != 1 || !region.queries[0].Forwarded {
+		t.Errorf("expected one forwarded query to the neighbour, got %v", region.queries)
+	}
+
+	// Far from the border and forwarded queries stay in the region
+	if _, err := server.GetNearbyCars(context.Background(), &api.NearbyRequest{Position: &api.Coordinate{X: 1, Y: 1}, Radius: 2}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = server.GetNearbyCars(context.Background(), &api.NearbyRequest{Position: &api.Coordinate{X: 7, Y: 4}, Radius: 2, Forwarded: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := identifiers(resp.Cars); len(ids) != 1 {
+		t.Errorf("expected only the own car for a forwarded query, got %v", ids)
+	}
+	if got := region.queryCount(); got != 1 {
+		t.Errorf("expected no further queries to the neighbour, got %d", got)
+	}
+}
+
+func TestHandOverWaitsForDispatchAndRedirectsCommands(t *testing.T) {
+	car := setupFleet(t)
+	region := setupShards(t)
+	crossed := &api.CarInfo{Identifier: car.address, Position: &api.Coordinate{X: 8, Y: 1}}
+
+	// An itinerary on its way to the car would be lost with the handover
+	carinfoMutex.Lock()
+	dispatching[car.address] = true
+	carinfoMutex.Unlock()
+	if err := shards.handOver(crossed, region.address); err == nil {
+		t.Fatal("handed over a car with an itinerary in flight")
+	}
+	release(car.address)
+	if err := shards.handOver(crossed, region.address); err != nil {
+		t.Fatal(err)
+	}
+	if len(region.received) != 1 {
+		t.Fatalf("expected the car to be handed over once, got %v", region.received)
+	}
+	carinfoMutex.Lock()
+	reserved := dispatching[car.address]
+	carinfoMutex.Unlock()
+	if reserved {
+		t.Error("car is still reserved for dispatching after the handover")
+	}
+
+	server := &CoordinatorServiceServer{}
+	_, err := server.SendCarCommand(context.Background(), &api.CarCommand{Identifier: car.address, Command: &api.Command{Type: api.CommandType_PAUSE}})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), region.address) {
+		t.Errorf("expected a redirect of the command to %s, got %v", region.address, err)
+	}
+	_, err = server.RecallCar(context.Background(), &api.CarRequest{Identifier: car.address})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), region.address) {
+		t.Errorf("expected a redirect of the recall to %s, got %v", region.address, err)
+	}
+	if car.recalls != 0 {
+		t.Errorf("recall reached the car %d times after the handover", car.recalls)
+	}
+}
@@ -0,0 +1,335 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/utils"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const reconcileTimeout = 30 * time.Second // trips of restored cars which did not report back by then are dispatched again
+
+// snapshot is the coordinator state written to disk. The grid is not stored,
+// it is rebuilt from the cars and the routes of the open trips.
+type snapshot struct {
+	Time       time.Time         `json:"time"`
+	FleetState string            `json:"fleet_state"`
+	TripSeq    int64             `json:"trip_seq"`
+	Cars       []json.RawMessage `json:"cars"` // protojson encoded CarInfos
+	Trips      []tripSnapshot    `json:"trips"`
+}
+
+// tripSnapshot is a trip which is neither completed nor cancelled.
+type tripSnapshot struct {
+	ID              string            `json:"id"`
+	Pickup          *api.Coordinate   `json:"pickup"`
+	Dropoff         *api.Coordinate   `json:"dropoff"`
+	Passengers      int32             `json:"passengers"`
+	Requirements    []string          `json:"requirements,omitempty"`
+	Route           []*api.Coordinate `json:"route"`
+	Car             string            `json:"car,omitempty"`
+	Requested       time.Time         `json:"requested"`
+	PromisedPickup  time.Time         `json:"promised_pickup"`
+	PromisedDropoff time.Time         `json:"promised_dropoff"`
+	PickedUp        time.Time         `json:"picked_up"`
+	Seen            bool              `json:"seen"`
+	Version         uint64            `json:"version,omitempty"`
+}
+
+var (
+	restoredCars  = make(map[string]bool) // cars from the snapshot which did not report since the restart
+	restoredMutex sync.Mutex
+)
+
+func takeSnapshot() (*snapshot, error) {
+	s := &snapshot{Time: time.Now().UTC(), TripSeq: tripSeq.Load()}
+
+	fleetMutex.Lock()
+	s.FleetState = fleetState.String()
+	fleetMutex.Unlock()
+
+	carinfoMutex.Lock()
+	for _, carInfo := range carinfos {
+		car, err := protojson.Marshal(carInfo)
+		if err != nil {
+			carinfoMutex.Unlock()
+			return nil, err
+		}
+		s.Cars = append(s.Cars, car)
+	}
+	carinfoMutex.Unlock()
+
+	tripMutex.Lock()
+	for _, t := range trips {
+		if t.cancelled || !t.droppedOff.IsZero() {
+			continue
+		}
+		s.Trips = append(s.Trips, snapshotTrip(t))
+	}
+	tripMutex.Unlock()
+	return s, nil
+}
+
+// snapshotTrip must be called with tripMutex held.
+func snapshotTrip(t *trip) tripSnapshot {
+	return tripSnapshot{
+		ID:              t.id,
+		Pickup:          t.pickup,
+		Dropoff:         t.dropoff,
+		Passengers:      t.passengers,
+		Requirements:    t.requirements,
+		Route:           t.route.Coordinates,
+		Car:             t.car,
+		Requested:       t.requested,
+		PromisedPickup:  t.promisedPickup,
+		PromisedDropoff: t.promisedDropoff,
+		PickedUp:        t.pickedUp,
+		Seen:            t.seen,
+		Version:         t.version,
+	}
+}
+
+func restoreTrip(ts tripSnapshot) *trip {
+	return &trip{
+		id:              ts.ID,
+		pickup:          ts.Pickup,
+		dropoff:         ts.Dropoff,
+		passengers:      ts.Passengers,
+		requirements:    ts.Requirements,
+		route:           &api.Route{Coordinates: ts.Route},
+		car:             ts.Car,
+		requested:       ts.Requested,
+		promisedPickup:  ts.PromisedPickup,
+		promisedDropoff: ts.PromisedDropoff,
+		pickupETA:       ts.PromisedPickup,
+		dropoffETA:      ts.PromisedDropoff,
+		pickedUp:        ts.PickedUp,
+		seen:            ts.Seen,
+		version:         ts.Version,
+	}
+}
+
+// encodeSnapshot returns the current coordinator state as JSON.
+func encodeSnapshot() ([]byte, error) {
+	s, err := takeSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// writeSnapshot replaces the snapshot file atomically.
+func writeSnapshot(path string) error {
+	data, err := encodeSnapshot()
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// snapshotPeriodically writes a snapshot every interval.
+func snapshotPeriodically(path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := writeSnapshot(path); err != nil {
+			logging.Component("snapshot").Warn("Failed to write snapshot", "path", path, "err", err)
+		}
+	}
+}
+
+// restoreSnapshot rebuilds the fleet view and the trip queue from the snapshot
+// at path, if there is one. With resume set it takes over the trips right
+// away, replicas wait until they lead.
+func restoreSnapshot(path string, resume bool) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s, err := loadSnapshot(data)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	if resume {
+		pending = resumeTrips()
+	}
+	logging.Component("snapshot").Info("Restored snapshot", "path", path, "taken", s.Time, "cars", len(s.Cars), "trips", len(s.Trips), "pending", pending)
+	return nil
+}
+
+// loadSnapshot replaces the fleet view, the trips and the fleet state with the
+// encoded snapshot. The grid is rebuilt from the cars and the trip routes.
+func loadSnapshot(data []byte) (*snapshot, error) {
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	cars := make([]*api.CarInfo, len(s.Cars))
+	for i, car := range s.Cars {
+		cars[i] = &api.CarInfo{}
+		if err := protojson.Unmarshal(car, cars[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	resetFleetView()
+	tripSeq.Store(s.TripSeq)
+	fleetMutex.Lock()
+	fleetState = api.FleetState(api.FleetState_value[s.FleetState])
+	fleetMutex.Unlock()
+
+	for _, carInfo := range cars {
+		updateCarinfo(carInfo)
+		carIndex.Update(carInfo.Identifier, carInfo.Position)
+	}
+	for _, ts := range s.Trips {
+		t := restoreTrip(ts)
+		registerTrip(t)
+		carinfoMutex.Lock()
+		updateGridDataRoute(t.route, carsByID[t.car].GetColor())
+		carinfoMutex.Unlock()
+	}
+	for _, carInfo := range cars {
+		updateGridData(nil, carInfo)
+	}
+	return &s, nil
+}
+
+// resetFleetView forgets all cars and trips and clears the grid.
+func resetFleetView() {
+	carinfoMutex.Lock()
+	for _, car := range carinfos {
+		carIndex.Remove(car.Identifier)
+	}
+	carinfos = carinfos[:0]
+	carsByID = make(map[string]*api.CarInfo)
+	dispatching = make(map[string]bool)
+	recalled = make(map[string]bool)
+	for x := range gridData {
+		for y := range gridData[x] {
+			gridData[x][y] = utils.EmptyCell(int32(x), int32(y))
+		}
+	}
+	carinfoMutex.Unlock()
+
+	tripMutex.Lock()
+	trips = make(map[string]*trip)
+	tripMutex.Unlock()
+
+	fleetMutex.Lock()
+	fleetState = api.FleetState_RUNNING
+	fleetMutex.Unlock()
+}
+
+// resumeTrips takes over the trips of a loaded state: pending trips are
+// dispatched again, assigned ones are reconciled once their car reports back.
+// It returns the number of pending trips.
+func resumeTrips() int {
+	carinfoMutex.Lock()
+	restoredMutex.Lock()
+	for _, carInfo := range carinfos {
+		restoredCars[carInfo.Identifier] = true
+	}
+	restoredMutex.Unlock()
+	carinfoMutex.Unlock()
+
+	tripMutex.Lock()
+	var pending []*trip
+	for _, t := range trips {
+		if t.car == "" && !t.cancelled && t.droppedOff.IsZero() {
+			pending = append(pending, t)
+		}
+	}
+	tripMutex.Unlock()
+
+	for _, t := range pending {
+		go dispatchTrip(t)
+	}
+	go requeueOrphanedTrips()
+	return len(pending)
+}
+
+// reconcileCar is called for every car update. On the first update of a
+// restored car it compares the car's itinerary with the snapshot: a lower
+// itinerary version means the car restarted as well and lost its trips, which
+// are dispatched again. It reports whether the car was restored.
+func reconcileCar(restored, carInfo *api.CarInfo) bool {
+	restoredMutex.Lock()
+	wasRestored := restoredCars[carInfo.Identifier]
+	delete(restoredCars, carInfo.Identifier)
+	restoredMutex.Unlock()
+	if !wasRestored {
+		return false
+	}
+
+	if restored != nil && carInfo.ItineraryVersion < restored.ItineraryVersion {
+		logging.Component("snapshot").Warn("Car restarted and lost its itinerary", logging.CarKey, carInfo.Identifier)
+		requeueTrips(carInfo.Identifier)
+	} else {
+		logging.Component("snapshot").Info("Car reconnected", logging.CarKey, carInfo.Identifier)
+	}
+	return true
+}
+
+// requeueOrphanedTrips dispatches the trips of restored cars again which did
+// not report back within reconcileTimeout.
+func requeueOrphanedTrips() {
+	time.Sleep(reconcileTimeout)
+	dropMissingCars()
+}
+
+// dropMissingCars removes the restored cars which did not report back from
+// the fleet view and dispatches their trips again.
+func dropMissingCars() {
+	restoredMutex.Lock()
+	missing := make([]string, 0, len(restoredCars))
+	for identifier := range restoredCars {
+		missing = append(missing, identifier)
+		delete(restoredCars, identifier)
+	}
+	restoredMutex.Unlock()
+
+	for _, identifier := range missing {
+		logging.Component("snapshot").Warn("Restored car did not report back", logging.CarKey, identifier)
+		removeCar(identifier)
+		requeueTrips(identifier)
+	}
+}
+
+// requeueTrips takes the open trips away from a car and dispatches them again.
+func requeueTrips(identifier string) {
+	tripMutex.Lock()
+	var orphaned []*trip
+	for _, t := range trips {
+		if t.car == identifier && !t.cancelled && t.droppedOff.IsZero() {
+			unassign(t)
+			orphaned = append(orphaned, t)
+		}
+	}
+	tripMutex.Unlock()
+
+	carinfoMutex.Lock()
+	for _, t := range orphaned {
+		updateGridDataRoute(t.route, "")
+	}
+	carinfoMutex.Unlock()
+
+	for _, t := range orphaned {
+		logging.Component("snapshot").Info("Dispatching orphaned trip again", logging.TripKey, t.id, logging.CarKey, identifier)
+		go dispatchTrip(t)
+	}
+}
@@ -0,0 +1,276 @@
+package coordinator
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"AutonomousCarFleetSimulation/logging"
+	"AutonomousCarFleetSimulation/utils"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const rideRequestTimeout = 10 * time.Second // how long RequestRide waits for a car
+
+var (
+	trips     = make(map[string]*trip)
+	tripMutex sync.Mutex
+	tripSeq   atomic.Int64
+
+	tripPrefix = "trip" // the region is added when the map is sharded, so ids stay unique
+)
+
+// newTrip creates a trip with the next free id and its direct route.
+func newTrip(pickup, dropoff *api.Coordinate, passengers int32, requirements []string) *trip {
+	return &trip{
+		id:           fmt.Sprintf("%s-%d", tripPrefix, tripSeq.Add(1)),
+		pickup:       pickup,
+		dropoff:      dropoff,
+		passengers:   passengers,
+		requirements: requirements,
+		requested:    time.Now(),
+		route:        &api.Route{Coordinates: utils.CalculatePath(pickup, dropoff, nil)},
+	}
+}
+
+func registerTrip(t *trip) {
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+	trips[t.id] = t
+	stateChanged()
+}
+
+// assignTrip records the car serving the trip, the itinerary version the car
+// acknowledged it with and the ETAs promised for it. It reports whether the
+// trip was cancelled before, in which case the cancellation did not reach the
+// car.
+func assignTrip(t *trip, identifier string, etas map[stopKey]time.Time, version uint64) bool {
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+	t.car = identifier
+	t.version = version
+	t.promisedPickup = etas[stopKey{t.id, api.StopType_PICKUP}]
+	t.promisedDropoff = etas[stopKey{t.id, api.StopType_DROPOFF}]
+	t.pickupETA = t.promisedPickup
+	t.dropoffETA = t.promisedDropoff
+	stateChanged()
+	return t.cancelled
+}
+
+// requestRide queues a trip and waits until a car was assigned to it, so the
+// response carries the promised ETAs. Trips still pending after
+// rideRequestTimeout are returned without car and stay queued.
+func requestRide(ctx context.Context, pickup, dropoff *api.Coordinate, passengers int32, requirements []string) (*api.RideResponse, error) {
+	size := int32(utils.Settings.GridSize)
+	for _, pos := range []*api.Coordinate{pickup, dropoff} {
+		if pos == nil || pos.X < 0 || pos.X >= size || pos.Y < 0 || pos.Y >= size {
+			return nil, fmt.Errorf("position %v is outside of the grid", pos)
+		}
+	}
+	if passengers <= 0 {
+		passengers = 1
+	}
+	if !servable(requirements) {
+		return nil, fmt.Errorf("no vehicle type fulfils %v", requirements)
+	}
+
+	t := newTrip(pickup, dropoff, passengers, requirements)
+	tripCh <- t
+	logging.Component("trips").Info("Ride requested", logging.TripKey, t.id, "passengers", passengers, "requirements", requirements, "pickup", pickup, "dropoff", dropoff)
+
+	ctx, cancel := context.WithTimeout(ctx, rideRequestTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		tripMutex.Lock()
+		if t.car != "" || t.cancelled {
+			status := rideStatus(t)
+			tripMutex.Unlock()
+			return status, nil
+		}
+		tripMutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			tripMutex.Lock()
+			defer tripMutex.Unlock()
+			return rideStatus(t), nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// servable reports whether any vehicle type fulfils the requirements.
+func servable(requirements []string) bool {
+	for _, vehicle := range utils.VehicleTypes {
+		if vehicle.Serves(requirements) {
+			return true
+		}
+	}
+	return false
+}
+
+// tripETA returns the latest ETAs of a trip.
+func tripETA(id string) (*api.RideResponse, error) {
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+
+	t, ok := trips[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown trip %s", id)
+	}
+	return rideStatus(t), nil
+}
+
+// unassign takes the trip away from its car, the passengers are picked up
+// again by the next car. Must be called with tripMutex held.
+func unassign(t *trip) {
+	t.car = ""
+	t.seen = false
+	t.version = 0
+	t.pickedUp = time.Time{}
+	stateChanged()
+}
+
+func isCancelled(t *trip) bool {
+	tripMutex.Lock()
+	defer tripMutex.Unlock()
+	return t.cancelled
+}
+
+// cancelTrip takes a trip back. Pending trips are dropped right away, assigned
+// ones are cancelled on the car, which reports the abort with its next update.
+func cancelTrip(id string) error {
+	tripMutex.Lock()
+	t, ok := trips[id]
+	if !ok {
+		tripMutex.Unlock()
+		return fmt.Errorf("unknown trip %s", id)
+	}
+	t.cancelled = true
+	identifier := t.car
+	tripMutex.Unlock()
+	stateChanged()
+	events.record(event{Type: eventTripCancelled, Trip: id, Car: identifier})
+
+	if identifier == "" {
+		logging.Component("trips").Info("Cancelled pending trip", logging.TripKey, id)
+		clearTripRoute(id)
+		return nil
+	}
+
+	return cancelOnCar(identifier, id)
+}
+
+// cancelOnCar removes the stops of a trip from the car's itinerary.
+func cancelOnCar(identifier, id string) error {
+	conn, err := dialCar(identifier)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ack, err := api.NewCarClientServiceClient(conn).CancelRoute(context.Background(), &api.CancelRequest{TripIds: []string{id}})
+	if err != nil {
+		return err
+	}
+	logging.Component("trips").Info("Cancelled trip", logging.TripKey, id, logging.CarKey, identifier, "response", ack.Message)
+	return nil
+}
+
+// tripAborted handles a trip the car dropped from its itinerary. Trips which
+// were not cancelled, e.g. by a recall, are dispatched again.
+func tripAborted(id, identifier string) {
+	tripMutex.Lock()
+	t, ok := trips[id]
+	if !ok || t.car != identifier {
+		tripMutex.Unlock()
+		return // Unknown here or already served by another car
+	}
+	requeue := !t.cancelled && t.droppedOff.IsZero()
+	if requeue {
+		unassign(t)
+	}
+	tripMutex.Unlock()
+
+	if !requeue {
+		clearTripRoute(id)
+		return
+	}
+	logging.Component("trips").Info("Dispatching aborted trip again", logging.TripKey, id, logging.CarKey, identifier)
+	carinfoMutex.Lock()
+	updateGridDataRoute(t.route, "")
+	carinfoMutex.Unlock()
+	go dispatchTrip(t)
+}
+
+// recallCar aborts all trips of a car and sends it back to its depot. The car
+// gets no trips until it arrived there, the aborted ones are dispatched again
+// once the car reports them. The car is marked before the call, so trips it
+// aborts meanwhile are not dispatched back to it.
+func recallCar(identifier string) error {
+	conn, err := dialCar(identifier)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	carinfoMutex.Lock()
+	wasRecalled := recalled[identifier]
+	recalled[identifier] = true
+	carinfoMutex.Unlock()
+
+	ack, err := api.NewCarClientServiceClient(conn).Recall(context.Background(), &api.Empty{})
+	if err != nil {
+		if !wasRecalled {
+			carinfoMutex.Lock()
+			delete(recalled, identifier)
+			carinfoMutex.Unlock()
+		}
+		return err
+	}
+	logging.Component("trips").Info("Recalled car", logging.CarKey, identifier, "response", ack.Message)
+	return nil
+}
+
+// returnedFromRecall puts a recalled car back into service once it is idle at its depot.
+func returnedFromRecall(carInfo *api.CarInfo) {
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+
+	if !recalled[carInfo.Identifier] || carInfo.ActiveRoute || int(carInfo.Depot) >= len(utils.Settings.Depots) {
+		return
+	}
+	depot := utils.Settings.Depots[carInfo.Depot]
+	if carInfo.Position.X == depot.X && carInfo.Position.Y == depot.Y {
+		delete(recalled, carInfo.Identifier)
+		logging.Component("trips").Info("Recalled car is back at its depot", logging.CarKey, carInfo.Identifier)
+	}
+}
+
+// clearTripRoute removes the route cells of an aborted trip from the grid.
+func clearTripRoute(id string) {
+	tripMutex.Lock()
+	t, ok := trips[id]
+	tripMutex.Unlock()
+	if !ok {
+		return
+	}
+
+	clearRoute(t.route)
+	shards.shareRoute(t.id, t.route, "", true)
+}
+
+// clearRoute removes the route cells which are not covered by a car.
+func clearRoute(route *api.Route) {
+	carinfoMutex.Lock()
+	defer carinfoMutex.Unlock()
+
+	for _, coord := range route.Coordinates {
+		if gridData[coord.X][coord.Y][0] == utils.Settings.RouteAscii {
+			gridData[coord.X][coord.Y] = utils.EmptyCell(coord.X, coord.Y)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package logging sets up the structured logger shared by the coordinator and
+// the car clients.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Keys of the fields attached to log records
+const (
+	CarKey       = "car"
+	TripKey      = "trip"
+	ComponentKey = "component"
+)
+
+// Setup installs the default logger writing to stderr. level is one of debug,
+// info, warn or error; json switches from text to JSON lines.
+func Setup(level string, json bool) error {
+	var lvl slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		lvl = slog.LevelDebug
+	case "info":
+		lvl = slog.LevelInfo
+	case "warn":
+		lvl = slog.LevelWarn
+	case "error":
+		lvl = slog.LevelError
+	default:
+		return fmt.Errorf("unknown log level %q, use debug, info, warn or error", level)
+	}
+
+	options := &slog.HandlerOptions{Level: lvl}
+	var handler slog.Handler = slog.NewTextHandler(os.Stderr, options)
+	if json {
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Component returns the default logger tagged with a component name.
+func Component(name string) *slog.Logger {
+	return slog.With(ComponentKey, name)
+}
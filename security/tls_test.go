@@ -0,0 +1,94 @@
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func writeDevCerts(t *testing.T, dir string) (caPath, certPath, keyPath string) {
+	t.Helper()
+	ca, caKey, err := NewCA("test CA", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, key, err := IssueCert(ca, caKey, "car-50001", DevHosts, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caPath, certPath, keyPath = filepath.Join(dir, "ca.pem"), filepath.Join(dir, "car.pem"), filepath.Join(dir, "car-key.pem")
+	if err := WriteKeyPair(ca, caKey, caPath, filepath.Join(dir, "ca-key.pem")); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteKeyPair(cert, key, certPath, keyPath); err != nil {
+		t.Fatal(err)
+	}
+	return caPath, certPath, keyPath
+}
+
+func check(address string, option grpc.DialOption) error {
+	conn, err := grpc.Dial(address, option)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	caPath, certPath, keyPath := writeDevCerts(t, t.TempDir())
+	if err := SetupTLS(caPath, certPath, keyPath); err != nil {
+		t.Fatal(err)
+	}
+	if !Enabled() {
+		t.Fatal("expected TLS to be enabled")
+	}
+
+	server := grpc.NewServer(ServerOption())
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+	address := fmt.Sprintf("localhost:%d", listener.Addr().(*net.TCPAddr).Port) // The certificates are issued for localhost
+
+	if err := check(address, DialOption()); err != nil {
+		t.Errorf("expected a certificate of the CA to be accepted: %v", err)
+	}
+
+	// A certificate of another CA and no certificate at all are rejected
+	_, foreignPath, foreignKeyPath := writeDevCerts(t, t.TempDir())
+	foreign, err := tls.LoadX509KeyPair(foreignPath, foreignKeyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _, err := ReadKeyPair(caPath, filepath.Join(filepath.Dir(caPath), "ca-key.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	for name, config := range map[string]*tls.Config{
+		"foreign certificate": {Certificates: []tls.Certificate{foreign}, RootCAs: pool},
+		"no certificate":      {RootCAs: pool},
+	} {
+		if err := check(address, grpc.WithTransportCredentials(credentials.NewTLS(config))); err == nil {
+			t.Errorf("expected a client with %s to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package security
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Roles of token holders
+const (
+	RoleCar         = "car"
+	RoleCoordinator = "coordinator"
+	RoleOperator    = "operator"
+)
+
+const tokenKey = "authorization" // metadata carrying the token as "Bearer <token>"
+
+var (
+	ErrNoToken      = errors.New("no token")
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = fmt.Errorf("%w: expired", ErrInvalidToken)
+)
+
+// Claims are the signed contents of a token.
+type Claims struct {
+	Subject string    `json:"sub"` // car id, "coordinator" or "operator"
+	Role    string    `json:"role"`
+	Address string    `json:"addr,omitempty"` // address the car serves gRPC on, or the car a coordinator token is for
+	Expires time.Time `json:"exp"`
+}
+
+// Issuer signs tokens with an ed25519 key.
+type Issuer struct {
+	key ed25519.PrivateKey
+}
+
+func NewIssuer(key ed25519.PrivateKey) *Issuer {
+	return &Issuer{key: key}
+}
+
+// LoadOrCreateKey reads the signing key at path. A missing file is created
+// with a new key, an empty path gives a key which lives as long as the process.
+func LoadOrCreateKey(path string) (ed25519.PrivateKey, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			block, _ := pem.Decode(data)
+			if block == nil {
+				return nil, fmt.Errorf("no PEM data in %s", path)
+			}
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			signingKey, ok := key.(ed25519.PrivateKey)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an ed25519 key", path)
+			}
+			return signingKey, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func (i *Issuer) PublicKey() ed25519.PublicKey {
+	return i.key.Public().(ed25519.PublicKey)
+}
+
+// Issue returns a token of the base64 encoded claims and their signature,
+// separated by a dot.
+func (i *Issuer) Issue(claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(i.key, payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks the signature and the expiry of a token. The claims of an
+// expired token are returned with ErrExpiredToken.
+func Verify(key ed25519.PublicKey, token string) (Claims, error) {
+	var claims Claims
+	if token == "" {
+		return claims, ErrNoToken
+	}
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok || len(key) != ed25519.PublicKeySize {
+		return claims, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !ed25519.Verify(key, payload, signature) {
+		return claims, ErrInvalidToken
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, ErrInvalidToken
+	}
+	if time.Now().After(claims.Expires) {
+		return claims, fmt.Errorf("%w at %s", ErrExpiredToken, claims.Expires)
+	}
+	return claims, nil
+}
+
+// WithToken attaches the token to an outgoing call.
+func WithToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, tokenKey, "Bearer "+token)
+}
+
+// TokenFromContext returns the token of an incoming call.
+func TokenFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(tokenKey) {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return token
+		}
+	}
+	return ""
+}
+
+// TokenInterceptor attaches a token from the source to every call.
+func TokenInterceptor(source func() (string, error)) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := source()
+		if err != nil {
+			return err
+		}
+		return invoker(WithToken(ctx, token), method, req, reply, cc, opts...)
+	}
+}
+
+// PeerName returns the common name of the client certificate of an incoming
+// call, empty without mutual TLS.
+func PeerName(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return ""
+	}
+	return info.State.PeerCertificates[0].Subject.CommonName
+}
@@ -0,0 +1,48 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signing.pem")
+	key, err := LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Coordinators sharing the key file verify each other's tokens
+	reloaded, err := LoadOrCreateKey(path)
+	if err != nil || !key.Equal(reloaded) {
+		t.Fatalf("expected the key to be reloaded, got %v", err)
+	}
+
+	issuer := NewIssuer(key)
+	claims := Claims{Subject: "car-50001", Role: RoleCar, Address: "localhost:50001", Expires: time.Now().Add(time.Minute)}
+	token, err := issuer.Issue(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Verify(issuer.PublicKey(), token)
+	if err != nil || got.Subject != claims.Subject || got.Role != RoleCar || got.Address != claims.Address {
+		t.Errorf("expected the claims back, got %+v, %v", got, err)
+	}
+
+	other, _ := LoadOrCreateKey("")
+	if _, err := Verify(other.Public().(ed25519.PublicKey), token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected a token of another key to be rejected, got %v", err)
+	}
+	if _, err := Verify(issuer.PublicKey(), token[:len(token)-2]+"AA"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected a tampered token to be rejected, got %v", err)
+	}
+	expired, _ := issuer.Issue(Claims{Subject: "car-50001", Role: RoleCar, Expires: time.Now().Add(-time.Second)})
+	if got, err := Verify(issuer.PublicKey(), expired); !errors.Is(err, ErrInvalidToken) || !errors.Is(err, ErrExpiredToken) || got.Subject != "car-50001" {
+		t.Errorf("expected an expired token to be rejected with its claims, got %+v, %v", got, err)
+	}
+	if _, err := Verify(issuer.PublicKey(), ""); !errors.Is(err, ErrNoToken) {
+		t.Errorf("expected a missing token to be reported, got %v", err)
+	}
+}
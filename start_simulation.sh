@@ -10,6 +10,7 @@ max_value=$2
 advanced_drive=$3
 
 colors=("Rot" "Grün" "Blau" "Cyan" "Magenta" "Orange" "Pink" "Lila" "Braun" "Schwarz")
+types=("car" "van" "car" "bus" "robot")
 
 echo "Starting server..."
 go run coordinator/cmd/main.go &
@@ -24,8 +25,9 @@ do
     color=${colors[$(( (i - 1) % ${#colors[@]} ))]}
     x=$((RANDOM % max_value))
     y=$((RANDOM % max_value))
-    echo "Starting car $i on port $port with color $color, x=$x, y=$y, advancedDrive=$advanced_drive..."
-    go run carclient/cmd/main.go --port=$port --color=$color --x=$x --y=$y --advancedDrive=$advanced_drive &
+    type=${types[$(( (i - 1) % ${#types[@]} ))]}
+    echo "Starting $type $i on port $port with color $color, x=$x, y=$y, advancedDrive=$advanced_drive..."
+    go run carclient/cmd/main.go --port=$port --color=$color --x=$x --y=$y --type=$type --advancedDrive=$advanced_drive &
 done
 
 read -p "Press any key to stop all processes..."
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"math"
+)
+
+// PoolingLimits bound how much a shared ride may deviate from a direct one
+type PoolingLimits struct {
+	Seats       int32   // passengers the car can carry at once
+	MaxDetour   float64 // allowed ride distance as multiple of the direct distance
+	DetourSlack float64 // cells every ride may exceed the detour factor by
+}
+
+// ItineraryLength returns the number of cells driven from pos through all stops
+func ItineraryLength(pos *api.Coordinate, stops []*api.Stop) float64 {
+	length := 0.0
+	for _, stop := range stops {
+		length += Distance(pos, stop.Position)
+		pos = stop.Position
+	}
+	return length
+}
+
+// FeasibleItinerary checks the seat capacity and the detour limit of every
+// trip in the itinerary. Trips without pickup stop are already on board.
+func FeasibleItinerary(pos *api.Coordinate, onboard int32, stops []*api.Stop, limits PoolingLimits) bool {
+	origin := pos
+	load := onboard
+	driven := 0.0
+	pickedUpAt := make(map[string]float64)
+	pickups := make(map[string]*api.Coordinate)
+
+	for _, stop := range stops {
+		driven += Distance(pos, stop.Position)
+		pos = stop.Position
+
+		switch stop.Type {
+		case api.StopType_PICKUP:
+			load += stop.Passengers
+			if load > limits.Seats {
+				return false
+			}
+			pickedUpAt[stop.TripId] = driven
+			pickups[stop.TripId] = stop.Position
+		case api.StopType_DROPOFF:
+			load -= stop.Passengers
+			ride := driven - pickedUpAt[stop.TripId]
+			start, ok := pickups[stop.TripId]
+			if !ok {
+				// Passenger is already on board, the ride is measured from now on
+				ride = driven
+				start = origin
+			}
+			if ride > limits.MaxDetour*Distance(start, stop.Position)+limits.DetourSlack {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// InsertTrip finds the cheapest feasible positions for the pickup and dropoff
+// of a new trip in an itinerary. It returns the new itinerary, the number of
+// additional cells to drive and whether any feasible insertion exists.
+func InsertTrip(pos *api.Coordinate, onboard int32, stops []*api.Stop, pickup, dropoff *api.Stop, limits PoolingLimits) ([]*api.Stop, float64, bool) {
+	baseLength := ItineraryLength(pos, stops)
+
+	var best []*api.Stop
+	bestCost := math.Inf(1)
+
+	for i := 0; i <= len(stops); i++ {
+		for j := i; j <= len(stops); j++ {
+			candidate := make([]*api.Stop, 0, len(stops)+2)
+			candidate = append(candidate, stops[:i]...)
+			candidate = append(candidate, pickup)
+			candidate = append(candidate, stops[i:j]...)
+			candidate = append(candidate, dropoff)
+			candidate = append(candidate, stops[j:]...)
+
+			if !FeasibleItinerary(pos, onboard, candidate, limits) {
+				continue
+			}
+			cost := ItineraryLength(pos, candidate) - baseLength
+			if cost < bestCost {
+				bestCost = cost
+				best = candidate
+			}
+		}
+	}
+	return best, bestCost, best != nil
+}
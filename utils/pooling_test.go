@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"testing"
+)
+
+func TestInsertTrip(t *testing.T) {
+	limits := PoolingLimits{Seats: 4, MaxDetour: 1.5, DetourSlack: 2}
+	pos := &api.Coordinate{X: 0, Y: 0}
+	stops := []*api.Stop{
+		{TripId: "a", Type: api.StopType_PICKUP, Position: &api.Coordinate{X: 2, Y: 0}, Passengers: 1},
+		{TripId: "a", Type: api.StopType_DROPOFF, Position: &api.Coordinate{X: 10, Y: 0}, Passengers: 1},
+	}
+
+	// A trip along the way is pooled without any detour
+	pickup := &api.Stop{TripId: "b", Type: api.StopType_PICKUP, Position: &api.Coordinate{X: 4, Y: 0}, Passengers: 2}
+	dropoff := &api.Stop{TripId: "b", Type: api.StopType_DROPOFF, Position: &api.Coordinate{X: 8, Y: 0}, Passengers: 2}
+	itinerary, cost, ok := InsertTrip(pos, 0, stops, pickup, dropoff, limits)
+	if !ok {
+		t.Fatalf("expected trip on the way to be pooled")
+	}
+	if cost != 0 {
+		t.Errorf("expected no additional cells, got %v", cost)
+	}
+	expected := []string{"a", "b", "b", "a"}
+	for i, stop := range itinerary {
+		if stop.TripId != expected[i] {
+			t.Errorf("expected stop %d to belong to trip %s, got %s", i, expected[i], stop.TripId)
+		}
+	}
+
+	// Overlapping rides must not exceed the seat capacity
+	pickup.Passengers, dropoff.Passengers = 4, 4
+	overlapping := []*api.Stop{stops[0], pickup, dropoff, stops[1]}
+	if FeasibleItinerary(pos, 0, overlapping, limits) {
+		t.Errorf("expected 5 passengers in a car with 4 seats to be infeasible")
+	}
+
+	// A trip far off the way would exceed the detour limit of trip a
+	pickup = &api.Stop{TripId: "c", Type: api.StopType_PICKUP, Position: &api.Coordinate{X: 3, Y: 10}, Passengers: 1}
+	dropoff = &api.Stop{TripId: "c", Type: api.StopType_DROPOFF, Position: &api.Coordinate{X: 3, Y: 15}, Passengers: 1}
+	detour := []*api.Stop{stops[0], pickup, dropoff, stops[1]}
+	if FeasibleItinerary(pos, 0, detour, limits) {
+		t.Errorf("expected detour for trip a to be infeasible")
+	}
+	if _, _, ok := InsertTrip(pos, 0, stops, pickup, dropoff, limits); !ok {
+		t.Errorf("expected trip c to be appended after trip a")
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import "AutonomousCarFleetSimulation/api"
+
+// Regions splits the grid into count vertical strips of nearly equal width.
+// When the map is sharded, each strip is owned by one coordinator.
+func Regions(count int) []Area {
+	size := Settings.GridSize
+	regions := make([]Area, count)
+	for i := range regions {
+		regions[i] = Area{
+			Min: &api.Coordinate{X: int32(i * size / count), Y: 0},
+			Max: &api.Coordinate{X: int32((i+1)*size/count - 1), Y: int32(size - 1)},
+		}
+	}
+	return regions
+}
+
+// RegionOf returns the index of the region containing pos, -1 outside the grid.
+func RegionOf(pos *api.Coordinate, regions []Area) int {
+	for i, region := range regions {
+		if region.Contains(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RegionsWithin returns the indices of all regions overlapping the square of
+// cells at most radius away from center along each axis.
+func RegionsWithin(center *api.Coordinate, radius int, regions []Area) []int {
+	var within []int
+	r := int32(radius)
+	for i, region := range regions {
+		if region.Min.X <= center.X+r && region.Max.X >= center.X-r && region.Min.Y <= center.Y+r && region.Max.Y >= center.Y-r {
+			within = append(within, i)
+		}
+	}
+	return within
+}
+
+// RegionsOnPath returns the indices of all regions the path crosses, in the
+// order they are entered.
+func RegionsOnPath(path []*api.Coordinate, regions []Area) []int {
+	var crossed []int
+	seen := make(map[int]bool)
+	for _, coord := range path {
+		region := RegionOf(coord, regions)
+		if region >= 0 && !seen[region] {
+			seen[region] = true
+			crossed = append(crossed, region)
+		}
+	}
+	return crossed
+}
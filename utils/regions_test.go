@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"testing"
+)
+
+func TestRegions(t *testing.T) {
+	regions := Regions(3)
+
+	// Every cell belongs to exactly one region
+	for x := int32(0); x < int32(Settings.GridSize); x++ {
+		for y := int32(0); y < int32(Settings.GridSize); y++ {
+			owners := 0
+			for _, region := range regions {
+				if region.Contains(&api.Coordinate{X: x, Y: y}) {
+					owners++
+				}
+			}
+			if owners != 1 {
+				t.Fatalf("cell %d,%d is in %d regions", x, y, owners)
+			}
+		}
+	}
+
+	last := &api.Coordinate{X: int32(Settings.GridSize - 1), Y: 0}
+	if got := RegionOf(last, regions); got != 2 {
+		t.Errorf("expected the last column in region 2, got %d", got)
+	}
+	if got := RegionOf(&api.Coordinate{X: -1, Y: 0}, regions); got != -1 {
+		t.Errorf("expected no region outside the grid, got %d", got)
+	}
+
+	if got := RegionsWithin(&api.Coordinate{X: 1, Y: 1}, 2, regions); len(got) != 1 || got[0] != 0 {
+		t.Errorf("expected only region 0 near the corner, got %v", got)
+	}
+	border := &api.Coordinate{X: regions[1].Min.X, Y: 5}
+	if got := RegionsWithin(border, 1, regions); len(got) != 2 || got[0] != 0 || got[1] != 1 {
+		t.Errorf("expected regions 0 and 1 at the border, got %v", got)
+	}
+
+	path := CalculatePath(last, &api.Coordinate{X: 0, Y: 0}, nil)
+	if got := RegionsOnPath(path, regions); len(got) != 3 || got[0] != 2 || got[2] != 0 {
+		t.Errorf("expected the path to cross regions 2, 1, 0, got %v", got)
+	}
+}
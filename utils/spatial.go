@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"sync"
+)
+
+type bucketKey struct {
+	x, y int32
+}
+
+// SpatialIndex buckets identifiers into square cells so that radius queries
+// only have to look at the buckets around the query position.
+type SpatialIndex struct {
+	mu        sync.RWMutex
+	cellSize  int32
+	buckets   map[bucketKey]map[string]*api.Coordinate
+	positions map[string]*api.Coordinate
+}
+
+func NewSpatialIndex(cellSize int) *SpatialIndex {
+	if cellSize < 1 {
+		cellSize = 1
+	}
+	return &SpatialIndex{
+		cellSize:  int32(cellSize),
+		buckets:   make(map[bucketKey]map[string]*api.Coordinate),
+		positions: make(map[string]*api.Coordinate),
+	}
+}
+
+func (s *SpatialIndex) bucketOf(pos *api.Coordinate) bucketKey {
+	return bucketKey{x: floorDiv(pos.X, s.cellSize), y: floorDiv(pos.Y, s.cellSize)}
+}
+
+func floorDiv(a, b int32) int32 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// Update inserts id at pos or moves it there if it is already indexed.
+func (s *SpatialIndex) Update(id string, pos *api.Coordinate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(id)
+	key := s.bucketOf(pos)
+	if s.buckets[key] == nil {
+		s.buckets[key] = make(map[string]*api.Coordinate)
+	}
+	s.buckets[key][id] = pos
+	s.positions[id] = pos
+}
+
+func (s *SpatialIndex) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(id)
+}
+
+func (s *SpatialIndex) remove(id string) {
+	old, ok := s.positions[id]
+	if !ok {
+		return
+	}
+	key := s.bucketOf(old)
+	delete(s.buckets[key], id)
+	if len(s.buckets[key]) == 0 {
+		delete(s.buckets, key)
+	}
+	delete(s.positions, id)
+}
+
+// Query returns the identifiers within the given Manhattan distance of center.
+func (s *SpatialIndex) Query(center *api.Coordinate, radius int) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := int32(radius)
+	min := s.bucketOf(&api.Coordinate{X: center.X - r, Y: center.Y - r})
+	max := s.bucketOf(&api.Coordinate{X: center.X + r, Y: center.Y + r})
+
+	var result []string
+	for bx := min.x; bx <= max.x; bx++ {
+		for by := min.y; by <= max.y; by++ {
+			for id, pos := range s.buckets[bucketKey{x: bx, y: by}] {
+				if Distance(center, pos) <= float64(radius) {
+					result = append(result, id)
+				}
+			}
+		}
+	}
+	return result
+}
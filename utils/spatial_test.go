@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"sort"
+	"testing"
+)
+
+func TestSpatialIndexQuery(t *testing.T) {
+	index := NewSpatialIndex(4)
+	index.Update("a", &api.Coordinate{X: 0, Y: 0})
+	index.Update("b", &api.Coordinate{X: 2, Y: 1})
+	index.Update("c", &api.Coordinate{X: 9, Y: 9})
+	index.Update("d", &api.Coordinate{X: 5, Y: 0})
+
+	got := index.Query(&api.Coordinate{X: 1, Y: 1}, 3)
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("expected [a b], got %v", got)
+	}
+
+	// Moving a car across buckets must drop it from its old bucket
+	index.Update("c", &api.Coordinate{X: 1, Y: 2})
+	index.Remove("a")
+	got = index.Query(&api.Coordinate{X: 1, Y: 1}, 3)
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("expected [b c], got %v", got)
+	}
+
+	if got := index.Query(&api.Coordinate{X: 9, Y: 9}, 1); len(got) != 0 {
+		t.Errorf("expected no cars around (9, 9), got %v", got)
+	}
+}
@@ -15,6 +15,12 @@ type DisplaySettings struct {
 	CarAscii         string
 	RouteAscii       string
 	CarAndRouteAscii string
+	DepotAscii       string
+	Depots           []*api.Coordinate
+	ChargerAscii     string
+	ChargingStations []*api.Coordinate
+	PedestrianAscii  string
+	PedestrianZones  []Area
 }
 
 func createEmptyString() string {
@@ -62,6 +68,23 @@ var Settings = DisplaySettings{
 	X|_||X\.__
 	(XXX_XX_X_\
 	=` + "`" + `-(_)--(_)-'`,
+	DepotAscii: "   _______\n  /   P   \\\n |  _____  |\n |_|     |_|",
+	Depots: []*api.Coordinate{
+		{X: 1, Y: 1},
+		{X: 14, Y: 14},
+		{X: 1, Y: 14},
+		{X: 14, Y: 1},
+	},
+	ChargerAscii: "   _____\n  | [+] |\n  |  Z  |__\n  |_____|  ",
+	ChargingStations: []*api.Coordinate{
+		{X: 7, Y: 7},
+		{X: 12, Y: 4},
+		{X: 4, Y: 12},
+	},
+	PedestrianAscii: " . . . . . .\n. . . . . . \n . . . . . .\n. . . . . . ",
+	PedestrianZones: []Area{
+		{Min: &api.Coordinate{X: 9, Y: 9}, Max: &api.Coordinate{X: 11, Y: 11}},
+	},
 }
 
 // CreateDataGrid erstellt ein zweidimensionales Array von Strings
@@ -72,12 +95,52 @@ func CreateDataGrid() [][][2]string {
 	for i := range gridData {
 		gridData[i] = make([][2]string, Settings.GridSize)
 		for j := range gridData[i] {
-			gridData[i][j] = [2]string{Settings.EmptyAscii, "E"} // Standardfarbe 'E'
+			gridData[i][j] = EmptyCell(int32(i), int32(j))
 		}
 	}
 	return gridData
 }
 
+// EmptyCell returns the content of a cell without cars or routes, which is a depot, a charging station or nothing
+func EmptyCell(x, y int32) [2]string {
+	if DepotAt(&api.Coordinate{X: x, Y: y}) >= 0 {
+		return [2]string{Settings.DepotAscii, "Depot"}
+	}
+	if ChargingStationAt(&api.Coordinate{X: x, Y: y}) >= 0 {
+		return [2]string{Settings.ChargerAscii, "Charger"}
+	}
+	if RoadClassAt(&api.Coordinate{X: x, Y: y}) == Pedestrian {
+		return [2]string{Settings.PedestrianAscii, "Pedestrian"}
+	}
+	return [2]string{Settings.EmptyAscii, "E"} // Standardfarbe 'E'
+}
+
+// IsFree reports whether a cell shows neither a car nor a route
+func IsFree(cell [2]string) bool {
+	return cell[0] == Settings.EmptyAscii || cell[0] == Settings.DepotAscii || cell[0] == Settings.ChargerAscii || cell[0] == Settings.PedestrianAscii
+}
+
+// DepotAt returns the index of the depot at pos or -1
+func DepotAt(pos *api.Coordinate) int {
+	for i, depot := range Settings.Depots {
+		if depot.X == pos.X && depot.Y == pos.Y {
+			return i
+		}
+	}
+	return -1
+}
+
+// NearestDepot returns the index of the depot closest to pos
+func NearestDepot(pos *api.Coordinate) int {
+	nearest := -1
+	for i, depot := range Settings.Depots {
+		if nearest == -1 || Distance(pos, depot) < Distance(pos, Settings.Depots[nearest]) {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
 func Distance(start *api.Coordinate, end *api.Coordinate) float64 {
 	return math.Abs(float64(start.X)-float64(end.X)) + math.Abs(float64(start.Y)-float64(end.Y))
 }
@@ -98,7 +161,21 @@ func CalculatePath(start *api.Coordinate, end *api.Coordinate, avoidRoute *api.R
 			avoidSet[key] = true
 		}
 	}
+	return findPath(start, end, func(coord *api.Coordinate) bool {
+		return avoidSet[fmt.Sprintf("%d,%d", coord.X, coord.Y)]
+	})
+}
+
+// CalculatePathFor returns the shortest path inside the grid which only uses
+// roads the vehicle is allowed to drive on, or nil if there is none
+func CalculatePathFor(start *api.Coordinate, end *api.Coordinate, vehicle VehicleType) []*api.Coordinate {
+	return findPath(start, end, func(coord *api.Coordinate) bool {
+		return !InGrid(coord) || !vehicle.Allows(coord)
+	})
+}
 
+// findPath sucht per Breitensuche den kürzesten Pfad, blockierte Felder werden umfahren
+func findPath(start *api.Coordinate, end *api.Coordinate, blocked func(*api.Coordinate) bool) []*api.Coordinate {
 	// BFS-Initialisierung
 	queue := list.New()
 	startStep := Step{Coord: start, Path: []*api.Coordinate{start}}
@@ -135,8 +212,8 @@ func CalculatePath(start *api.Coordinate, end *api.Coordinate, avoidRoute *api.R
 			newCoord := &api.Coordinate{X: newX, Y: newY}
 			key := fmt.Sprintf("%d,%d", newX, newY)
 
-			// Überprüfen, ob die neue Koordinate in der avoidRoute liegt oder bereits besucht wurde
-			if (newX != end.X || newY != end.Y) && avoidSet[key] {
+			// Überprüfen, ob die neue Koordinate blockiert ist oder bereits besucht wurde
+			if (newX != end.X || newY != end.Y) && blocked(newCoord) {
 				continue
 			}
 			if visited[key] {
@@ -154,3 +231,30 @@ func CalculatePath(start *api.Coordinate, end *api.Coordinate, avoidRoute *api.R
 	// Kein Pfad gefunden
 	return nil
 }
+
+// InGrid reports whether pos lies on the grid
+func InGrid(pos *api.Coordinate) bool {
+	size := int32(Settings.GridSize)
+	return pos.X >= 0 && pos.X < size && pos.Y >= 0 && pos.Y < size
+}
+
+// ChargingStationAt returns the index of the charging station at pos or -1
+func ChargingStationAt(pos *api.Coordinate) int {
+	for i, station := range Settings.ChargingStations {
+		if station.X == pos.X && station.Y == pos.Y {
+			return i
+		}
+	}
+	return -1
+}
+
+// NearestChargingStation returns the charging station closest to pos
+func NearestChargingStation(pos *api.Coordinate) *api.Coordinate {
+	var nearest *api.Coordinate
+	for _, station := range Settings.ChargingStations {
+		if nearest == nil || Distance(pos, station) < Distance(pos, nearest) {
+			nearest = station
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"sort"
+)
+
+// RoadClass is the kind of road a cell belongs to
+type RoadClass int
+
+const (
+	Street     RoadClass = iota // regular streets, open to every vehicle
+	Lane                        // narrow streets along the grid border, too small for buses
+	Pedestrian                  // pedestrian zones, only delivery robots may enter
+)
+
+// Area is a rectangle of cells, including its corners
+type Area struct {
+	Min *api.Coordinate
+	Max *api.Coordinate
+}
+
+func (a Area) Contains(pos *api.Coordinate) bool {
+	return pos.X >= a.Min.X && pos.X <= a.Max.X && pos.Y >= a.Min.Y && pos.Y <= a.Max.Y
+}
+
+// RoadClassAt returns the road class of the cell at pos
+func RoadClassAt(pos *api.Coordinate) RoadClass {
+	for _, zone := range Settings.PedestrianZones {
+		if zone.Contains(pos) {
+			return Pedestrian
+		}
+	}
+	last := int32(Settings.GridSize - 1)
+	if pos.X == 0 || pos.Y == 0 || pos.X == last || pos.Y == last {
+		return Lane
+	}
+	return Street
+}
+
+// Requirements a trip can have and vehicles can fulfil
+const (
+	Passengers = "passengers"
+	Wheelchair = "wheelchair"
+	Cargo      = "cargo"
+)
+
+// VehicleType describes what a kind of vehicle can carry and where it can drive
+type VehicleType struct {
+	Name         string
+	Seats        int32   // default seat capacity
+	MaxSpeed     float64 // default top speed in cells per second
+	RoadClasses  []RoadClass
+	Capabilities []string
+	Ascii        string
+}
+
+const DefaultVehicleType = "car"
+
+var VehicleTypes = map[string]VehicleType{
+	"car": {
+		Name:         "car",
+		Seats:        4,
+		MaxSpeed:     1,
+		RoadClasses:  []RoadClass{Street, Lane},
+		Capabilities: []string{Passengers},
+		Ascii:        Settings.CarAscii,
+	},
+	"van": {
+		Name:         "van",
+		Seats:        8,
+		MaxSpeed:     0.8,
+		RoadClasses:  []RoadClass{Street, Lane},
+		Capabilities: []string{Passengers, Wheelchair, Cargo},
+		Ascii:        "  _______\n |  |__|_\\_\n |   _   _ |\n '-(_)--(_)'",
+	},
+	"bus": {
+		Name:         "bus",
+		Seats:        20,
+		MaxSpeed:     0.6,
+		RoadClasses:  []RoadClass{Street},
+		Capabilities: []string{Passengers, Wheelchair},
+		Ascii:        " ___________\n|[][][][]|  |\n|   _    _  |\n'-(_)---(_)-'",
+	},
+	"robot": {
+		Name:         "robot",
+		Seats:        2,
+		MaxSpeed:     0.4,
+		RoadClasses:  []RoadClass{Street, Lane, Pedestrian},
+		Capabilities: []string{Cargo},
+		Ascii:        "    [oo]\n   /|__|\\\n    |  |\n   (o)(o)",
+	},
+}
+
+// LookupVehicleType returns the vehicle type with the given name and whether it exists.
+// Unknown names fall back to the default car.
+func LookupVehicleType(name string) (VehicleType, bool) {
+	vehicle, ok := VehicleTypes[name]
+	if !ok {
+		return VehicleTypes[DefaultVehicleType], false
+	}
+	return vehicle, true
+}
+
+// VehicleTypeNames returns the names of all vehicle types, sorted
+func VehicleTypeNames() []string {
+	names := make([]string, 0, len(VehicleTypes))
+	for name := range VehicleTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Allows reports whether the vehicle may drive on the cell at pos
+func (v VehicleType) Allows(pos *api.Coordinate) bool {
+	class := RoadClassAt(pos)
+	for _, allowed := range v.RoadClasses {
+		if allowed == class {
+			return true
+		}
+	}
+	return false
+}
+
+// NearestAllowed returns the cell closest to pos the vehicle may drive on,
+// pos itself if it is allowed
+func (v VehicleType) NearestAllowed(pos *api.Coordinate) *api.Coordinate {
+	if InGrid(pos) && v.Allows(pos) {
+		return pos
+	}
+	var nearest *api.Coordinate
+	size := int32(Settings.GridSize)
+	for x := int32(0); x < size; x++ {
+		for y := int32(0); y < size; y++ {
+			cell := &api.Coordinate{X: x, Y: y}
+			if v.Allows(cell) && (nearest == nil || Distance(pos, cell) < Distance(pos, nearest)) {
+				nearest = cell
+			}
+		}
+	}
+	return nearest
+}
+
+// Serves reports whether the vehicle fulfils all requirements of a trip.
+// Trips without requirements carry passengers.
+func (v VehicleType) Serves(requirements []string) bool {
+	if len(requirements) == 0 {
+		requirements = []string{Passengers}
+	}
+	for _, requirement := range requirements {
+		found := false
+		for _, capability := range v.Capabilities {
+			if capability == requirement {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// IsVehicle reports whether a cell shows a vehicle of any type
+func IsVehicle(content string) bool {
+	for _, vehicle := range VehicleTypes {
+		if content == vehicle.Ascii {
+			return true
+		}
+	}
+	return false
+}
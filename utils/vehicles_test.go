@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"AutonomousCarFleetSimulation/api"
+	"testing"
+)
+
+func TestVehicleTypes(t *testing.T) {
+	bus, _ := LookupVehicleType("bus")
+	robot, _ := LookupVehicleType("robot")
+	van, _ := LookupVehicleType("van")
+
+	if bus.Allows(&api.Coordinate{X: 0, Y: 5}) {
+		t.Errorf("expected buses to stay off the border lanes")
+	}
+	if !robot.Allows(&api.Coordinate{X: 10, Y: 10}) || van.Allows(&api.Coordinate{X: 10, Y: 10}) {
+		t.Errorf("expected only robots in the pedestrian zone")
+	}
+	if !van.Serves([]string{Wheelchair, Cargo}) || bus.Serves([]string{Cargo}) || robot.Serves(nil) {
+		t.Errorf("unexpected capabilities")
+	}
+
+	// A van drives around the pedestrian zone, a robot straight through it
+	start, end := &api.Coordinate{X: 8, Y: 10}, &api.Coordinate{X: 12, Y: 10}
+	if path := CalculatePathFor(start, end, robot); len(path) != 5 {
+		t.Errorf("expected robot to cross the zone in 4 cells, got %v", path)
+	}
+	for _, coord := range CalculatePathFor(start, end, van) {
+		if RoadClassAt(coord) == Pedestrian {
+			t.Errorf("van entered the pedestrian zone at %v", coord)
+		}
+	}
+}
+
+func TestNearestAllowed(t *testing.T) {
+	bus, _ := LookupVehicleType("bus")
+	car, _ := LookupVehicleType("car")
+	robot, _ := LookupVehicleType("robot")
+
+	if got := bus.NearestAllowed(&api.Coordinate{X: 0, Y: 0}); got.X != 1 || got.Y != 1 {
+		t.Errorf("expected a bus in the corner lanes to move to (1,1), got %v", got)
+	}
+	if got := car.NearestAllowed(&api.Coordinate{X: 10, Y: 10}); RoadClassAt(got) == Pedestrian || Distance(got, &api.Coordinate{X: 10, Y: 10}) != 2 {
+		t.Errorf("expected a car to move to the edge of the pedestrian zone, got %v", got)
+	}
+	if got := robot.NearestAllowed(&api.Coordinate{X: 10, Y: 10}); got.X != 10 || got.Y != 10 {
+		t.Errorf("expected a robot to stay in the pedestrian zone, got %v", got)
+	}
+}